@@ -0,0 +1,256 @@
+package si
+
+import (
+	"errors"
+	"math"
+)
+
+// Sub subtracts two units of the same dimension.
+// Subtracting two absolute temperatures yields their difference in kelvins, and
+// subtracting a difference from an absolute temperature keeps the absolute scale.
+//
+// Example:
+//
+//	// Gauge pressure from absolute pressure
+//	gauge, _ := Psi(32.5).Sub(Psi(14.7)) // 17.8 psi
+//	rise, _ := Celsius(30).Sub(Celsius(21))  // 9 K
+func (u Unit) Sub(v Unit) (Unit, error) {
+	if u.Dimension != v.Dimension {
+		return Unit{}, &DimensionMismatchError{Op: "subtract", Left: u.Dimension, Right: v.Dimension}
+	}
+	kind, err := mergeKinds("subtract", u, v)
+	if err != nil {
+		return Unit{}, err
+	}
+	if v.IsAbsolute() && !u.IsAbsolute() {
+		return Unit{}, &AbsoluteTemperatureError{Op: "subtract", Left: u, Right: v}
+	}
+
+	// The difference of two absolute points is itself a difference
+	offset := u.Offset
+	if v.IsAbsolute() {
+		offset = 0
+	}
+	return Unit{Value: u.Value - v.Value, Dimension: u.Dimension, Offset: offset, Kind: kind}, nil
+}
+
+// Neg returns the unit with its value negated.
+// Like Mul, the result is a difference rather than an absolute temperature.
+//
+// Example:
+//
+//	drop := Pascals(250).Neg() // -250 Pa
+func (u Unit) Neg() Unit {
+	return Unit{Value: -u.Value, Dimension: u.Dimension, Kind: u.Kind}
+}
+
+// Abs returns the unit with the absolute value of its magnitude.
+//
+// Example:
+//
+//	magnitude := Newtons(-12).Abs() // 12 N
+func (u Unit) Abs() Unit {
+	return Unit{Value: math.Abs(u.Value), Dimension: u.Dimension, Offset: u.Offset, Kind: u.Kind}
+}
+
+// Scale multiplies the value by a plain number, keeping the dimension.
+// This is shorthand for u.Mul(Scalar(factor)).
+//
+// Example:
+//
+//	// Three identical loads
+//	total := Watts(60).Scale(3) // 180 W
+func (u Unit) Scale(factor float64) Unit {
+	return Unit{Value: u.Value * factor, Dimension: u.Dimension, Kind: u.Kind}
+}
+
+// Inv returns the reciprocal of a unit, negating every dimension exponent.
+//
+// Example:
+//
+//	// Period to frequency
+//	freq := Milliseconds(20).Inv() // 50 Hz
+func (u Unit) Inv() Unit {
+	return Unit{
+		Value:     1 / u.Value,
+		Dimension: powDimension(u.Dimension, -1, 1),
+	}
+}
+
+// Min returns the smaller of two units of the same dimension.
+//
+// Example:
+//
+//	shortest, _ := Kilometers(1).Min(Meters(1500)) // 1 km
+func (u Unit) Min(v Unit) (Unit, error) {
+	if u.Dimension != v.Dimension {
+		return Unit{}, &DimensionMismatchError{Op: "compare", Left: u.Dimension, Right: v.Dimension}
+	}
+	if _, err := mergeKinds("compare", u, v); err != nil {
+		return Unit{}, err
+	}
+	if v.Value < u.Value {
+		return v, nil
+	}
+	return u, nil
+}
+
+// Max returns the larger of two units of the same dimension.
+//
+// Example:
+//
+//	longest, _ := Kilometers(1).Max(Meters(1500)) // 1.5 km
+func (u Unit) Max(v Unit) (Unit, error) {
+	if u.Dimension != v.Dimension {
+		return Unit{}, &DimensionMismatchError{Op: "compare", Left: u.Dimension, Right: v.Dimension}
+	}
+	if _, err := mergeKinds("compare", u, v); err != nil {
+		return Unit{}, err
+	}
+	if v.Value > u.Value {
+		return v, nil
+	}
+	return u, nil
+}
+
+// Clamp limits the unit to the range [lo, hi], which must share its dimension.
+//
+// Example:
+//
+//	// Keep a setpoint inside the actuator range
+//	setpoint, _ := Celsius(95).Clamp(Celsius(5), Celsius(80)) // 80 °C
+func (u Unit) Clamp(lo, hi Unit) (Unit, error) {
+	if u.Dimension != lo.Dimension {
+		return Unit{}, &DimensionMismatchError{Op: "clamp", Left: u.Dimension, Right: lo.Dimension}
+	}
+	if u.Dimension != hi.Dimension {
+		return Unit{}, &DimensionMismatchError{Op: "clamp", Left: u.Dimension, Right: hi.Dimension}
+	}
+	for _, bound := range []Unit{lo, hi} {
+		if _, err := mergeKinds("clamp", u, bound); err != nil {
+			return Unit{}, err
+		}
+	}
+	if lo.Value > hi.Value {
+		return Unit{}, errors.New("cannot clamp to a range whose lower bound exceeds its upper bound")
+	}
+
+	switch {
+	case u.Value < lo.Value:
+		return lo, nil
+	case u.Value > hi.Value:
+		return hi, nil
+	default:
+		return u, nil
+	}
+}
+
+// Floor rounds the unit down to a whole multiple of step.
+// See Round for how absolute temperatures are handled.
+//
+// Example:
+//
+//	// Bill in started kilowatt-hours
+//	billed, _ := Joules(4.2e6).Floor(Hours(1).Mul(Watts(1000))) // 3.6 MJ
+func (u Unit) Floor(step Unit) (Unit, error) {
+	return u.roundTo(step, "floor", math.Floor)
+}
+
+// Round rounds the unit to the nearest whole multiple of step, with halves away from zero.
+// Absolute temperatures are rounded on their own scale, so a reading parsed from
+// "21.37 °C" rounded to Kelvins(0.5) is 21.5 °C. An absolute step such as
+// MustParse("1 °C") rounds on the step's scale instead.
+//
+// Example:
+//
+//	// Report pressure to the nearest 0.5 kPa
+//	reported, _ := Pascals(101325).Round(Pascals(500)) // 101.5 kPa
+func (u Unit) Round(step Unit) (Unit, error) {
+	return u.roundTo(step, "round", math.Round)
+}
+
+// roundTo counts steps from the zero point of the relevant scale, rounds that count
+// with the given function and converts back, keeping the unit's own offset.
+func (u Unit) roundTo(step Unit, op string, round func(float64) float64) (Unit, error) {
+	if u.Dimension != step.Dimension {
+		return Unit{}, &DimensionMismatchError{Op: op, Left: u.Dimension, Right: step.Dimension}
+	}
+	kind, err := mergeKinds(op, u, step)
+	if err != nil {
+		return Unit{}, err
+	}
+	if step.step() == 0 {
+		return Unit{}, errors.New("cannot " + op + " to a step of zero")
+	}
+
+	origin := u.Offset
+	if step.IsAbsolute() {
+		origin = step.Offset
+	}
+
+	n := round((u.Value - origin) / step.step())
+	return Unit{Value: origin + n*step.step(), Dimension: u.Dimension, Offset: u.Offset, Kind: kind}, nil
+}
+
+// Sum adds any number of units of the same dimension.
+// At most one of them may be an absolute temperature, as with Add.
+// It returns ErrNoUnits when called without arguments.
+//
+// Example:
+//
+//	// Total energy of a batch of readings
+//	total, _ := Sum(Joules(120), Joules(80), Watts(1000).Mul(Seconds(2))) // 2.2 kJ
+func Sum(units ...Unit) (Unit, error) {
+	if len(units) == 0 {
+		return Unit{}, ErrNoUnits
+	}
+
+	total := units[0]
+	for _, u := range units[1:] {
+		if total.Dimension != u.Dimension {
+			return Unit{}, &DimensionMismatchError{Op: "add", Left: total.Dimension, Right: u.Dimension}
+		}
+
+		var err error
+		if total, err = total.Add(u); err != nil {
+			return Unit{}, err
+		}
+	}
+	return total, nil
+}
+
+// Mean returns the arithmetic mean of units of the same dimension.
+// Unlike Sum it accepts absolute temperatures, provided all of them are absolute,
+// or else the error is an AbsoluteTemperatureError; the mean keeps the scale of
+// the first one. Kinds are checked as Add does, so energies and torques cannot
+// be averaged together.
+// It returns ErrNoUnits when called without arguments.
+//
+// Example:
+//
+//	// Average of sensor readings
+//	avg, _ := Mean(MustParse("20.5 °C"), MustParse("22.5 °C")) // 21.5 °C
+func Mean(units ...Unit) (Unit, error) {
+	if len(units) == 0 {
+		return Unit{}, ErrNoUnits
+	}
+
+	first := units[0]
+	kind := first.Kind
+	sum := 0.0
+	for _, u := range units {
+		if first.Dimension != u.Dimension {
+			return Unit{}, &DimensionMismatchError{Op: "average", Left: first.Dimension, Right: u.Dimension}
+		}
+		var err error
+		if kind, err = mergeKinds("average", Unit{Kind: kind}, u); err != nil {
+			return Unit{}, err
+		}
+		if first.IsAbsolute() != u.IsAbsolute() {
+			return Unit{}, &AbsoluteTemperatureError{Op: "average", Left: first, Right: u}
+		}
+		sum += u.Value
+	}
+
+	return Unit{Value: sum / float64(len(units)), Dimension: first.Dimension, Offset: first.Offset, Kind: kind}, nil
+}
@@ -0,0 +1,244 @@
+package si_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestSub verifies subtraction of units with the same dimension
+func TestSub(t *testing.T) {
+	got, err := si.Psi(32.5).Sub(si.Psi(14.7))
+	if err != nil {
+		t.Fatalf("Sub error: %v", err)
+	}
+	if want := si.Psi(17.8); math.Abs(got.Value-want.Value) > 1e-9 {
+		t.Errorf("Value = %v, want %v", got.Value, want.Value)
+	}
+}
+
+// TestSubDimensionMismatch verifies the typed error for mismatched dimensions
+func TestSubDimensionMismatch(t *testing.T) {
+	_, err := si.Meters(1).Sub(si.Seconds(1))
+
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *DimensionMismatchError, got %v", err)
+	}
+	if mismatch.Op != "subtract" || mismatch.Left != si.Length || mismatch.Right != si.Second.Dimension {
+		t.Errorf("Unexpected error fields: %+v", mismatch)
+	}
+}
+
+// TestSubAbsoluteTemperatures verifies two absolute temperatures give a difference
+func TestSubAbsoluteTemperatures(t *testing.T) {
+	got, err := si.MustParse("30 °C").Sub(si.MustParse("21 °C"))
+	if err != nil {
+		t.Fatalf("Sub error: %v", err)
+	}
+	if got.IsAbsolute() {
+		t.Error("Expected a difference")
+	}
+	if math.Abs(got.Value-9) > 1e-9 {
+		t.Errorf("Value = %v, want 9", got.Value)
+	}
+}
+
+// TestSubDeltaFromAbsolute verifies an absolute temperature minus a difference stays absolute
+func TestSubDeltaFromAbsolute(t *testing.T) {
+	got, err := si.MustParse("30 °C").Sub(si.Kelvins(5))
+	if err != nil {
+		t.Fatalf("Sub error: %v", err)
+	}
+	if got.String() != "25 °C" {
+		t.Errorf("String = %q, want %q", got.String(), "25 °C")
+	}
+}
+
+// TestSubAbsoluteFromDelta verifies subtracting an absolute temperature from a difference fails
+func TestSubAbsoluteFromDelta(t *testing.T) {
+	if _, err := si.Kelvins(5).Sub(si.MustParse("30 °C")); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+// TestNeg verifies negation keeps the dimension
+func TestNeg(t *testing.T) {
+	got := si.Pascals(250).Neg()
+	if got.Value != -250 || got.Dimension != si.Pascal.Dimension {
+		t.Errorf("Neg = %v", got)
+	}
+}
+
+// TestAbs verifies the magnitude of a negative unit
+func TestAbs(t *testing.T) {
+	got := si.Newtons(-12).Abs()
+	if got.Value != 12 || got.Dimension != si.Newton.Dimension {
+		t.Errorf("Abs = %v", got)
+	}
+}
+
+// TestScale verifies scaling by a plain number
+func TestScale(t *testing.T) {
+	got := si.Watts(60).Scale(3)
+	if got.Value != 180 || got.Dimension != si.Watt.Dimension {
+		t.Errorf("Scale = %v", got)
+	}
+}
+
+// TestInv verifies a period inverts to a frequency
+func TestInv(t *testing.T) {
+	got := si.Milliseconds(20).Inv()
+	if got.Dimension != si.Hertz.Dimension {
+		t.Errorf("Dimension = %v, want %v", got.Dimension, si.Hertz.Dimension)
+	}
+	if math.Abs(got.Value-50) > 1e-9 {
+		t.Errorf("Value = %v, want 50", got.Value)
+	}
+}
+
+// TestMinMax verifies both orderings pick the right operand
+func TestMinMax(t *testing.T) {
+	a, b := si.Kilometers(1), si.Meters(1500)
+
+	if got, _ := a.Min(b); got != a {
+		t.Errorf("Min = %v, want %v", got, a)
+	}
+	if got, _ := b.Min(a); got != a {
+		t.Errorf("Min = %v, want %v", got, a)
+	}
+	if got, _ := a.Max(b); got != b {
+		t.Errorf("Max = %v, want %v", got, b)
+	}
+	if got, _ := b.Max(a); got != b {
+		t.Errorf("Max = %v, want %v", got, b)
+	}
+}
+
+// TestMinDimensionMismatch verifies Min rejects mismatched dimensions
+func TestMinDimensionMismatch(t *testing.T) {
+	var mismatch *si.DimensionMismatchError
+	if _, err := si.Meters(1).Min(si.Kilograms(1)); !errors.As(err, &mismatch) {
+		t.Errorf("Expected *DimensionMismatchError, got %v", err)
+	}
+}
+
+// TestClamp verifies values outside the range snap to the bounds
+func TestClamp(t *testing.T) {
+	lo, hi := si.Celsius(5), si.Celsius(80)
+
+	if got, _ := si.Celsius(95).Clamp(lo, hi); got != hi {
+		t.Errorf("Clamp above = %v, want %v", got, hi)
+	}
+	if got, _ := si.Celsius(-10).Clamp(lo, hi); got != lo {
+		t.Errorf("Clamp below = %v, want %v", got, lo)
+	}
+	in := si.Celsius(20)
+	if got, _ := in.Clamp(lo, hi); got != in {
+		t.Errorf("Clamp inside = %v, want %v", got, in)
+	}
+}
+
+// TestClampInvertedRange verifies lo must not exceed hi
+func TestClampInvertedRange(t *testing.T) {
+	if _, err := si.Meters(1).Clamp(si.Meters(2), si.Meters(0)); err == nil {
+		t.Error("Expected error for inverted range")
+	}
+}
+
+// TestRound verifies rounding to a step
+func TestRound(t *testing.T) {
+	got, err := si.Pascals(101325).Round(si.Pascals(500))
+	if err != nil {
+		t.Fatalf("Round error: %v", err)
+	}
+	if got.Value != 101500 {
+		t.Errorf("Value = %v, want 101500", got.Value)
+	}
+}
+
+// TestFloor verifies flooring to a step in another unit
+func TestFloor(t *testing.T) {
+	kWh := si.Hours(1).Mul(si.Watts(1000))
+	got, err := si.Joules(4.2e6).Floor(kWh)
+	if err != nil {
+		t.Fatalf("Floor error: %v", err)
+	}
+	if math.Abs(got.Value-3.6e6) > 1e-6 {
+		t.Errorf("Value = %v, want 3.6e6", got.Value)
+	}
+}
+
+// TestRoundAbsoluteTemperature verifies rounding happens on the reading's own scale
+func TestRoundAbsoluteTemperature(t *testing.T) {
+	got, err := si.MustParse("21.37 °C").Round(si.Kelvins(0.5))
+	if err != nil {
+		t.Fatalf("Round error: %v", err)
+	}
+	if got.String() != "21.5 °C" {
+		t.Errorf("String = %q, want %q", got.String(), "21.5 °C")
+	}
+}
+
+// TestRoundZeroStep verifies a zero step is rejected
+func TestRoundZeroStep(t *testing.T) {
+	if _, err := si.Meters(1).Round(si.Meters(0)); err == nil {
+		t.Error("Expected error for zero step")
+	}
+}
+
+// TestSum verifies variadic addition
+func TestSum(t *testing.T) {
+	got, err := si.Sum(si.Joules(120), si.Joules(80), si.Watts(1000).Mul(si.Seconds(2)))
+	if err != nil {
+		t.Fatalf("Sum error: %v", err)
+	}
+	if got.Value != 2200 {
+		t.Errorf("Value = %v, want 2200", got.Value)
+	}
+}
+
+// TestSumEmpty verifies Sum without units returns ErrNoUnits
+func TestSumEmpty(t *testing.T) {
+	if _, err := si.Sum(); !errors.Is(err, si.ErrNoUnits) {
+		t.Errorf("Expected ErrNoUnits, got %v", err)
+	}
+}
+
+// TestSumDimensionMismatch verifies Sum reports the mismatching dimensions
+func TestSumDimensionMismatch(t *testing.T) {
+	var mismatch *si.DimensionMismatchError
+	if _, err := si.Sum(si.Meters(1), si.Meters(2), si.Seconds(3)); !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *DimensionMismatchError, got %v", err)
+	}
+	if mismatch.Right != si.Second.Dimension {
+		t.Errorf("Right = %v, want %v", mismatch.Right, si.Second.Dimension)
+	}
+}
+
+// TestMeanAbsoluteTemperatures verifies the mean of absolute temperatures keeps the scale
+func TestMeanAbsoluteTemperatures(t *testing.T) {
+	got, err := si.Mean(si.MustParse("20.5 °C"), si.MustParse("22.5 °C"))
+	if err != nil {
+		t.Fatalf("Mean error: %v", err)
+	}
+	if got.String() != "21.5 °C" {
+		t.Errorf("String = %q, want %q", got.String(), "21.5 °C")
+	}
+}
+
+// TestMeanMixedTemperatures verifies absolute temperatures and differences cannot be averaged
+func TestMeanMixedTemperatures(t *testing.T) {
+	if _, err := si.Mean(si.MustParse("20 °C"), si.Kelvins(5)); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+// TestMeanEmpty verifies Mean without units returns ErrNoUnits
+func TestMeanEmpty(t *testing.T) {
+	if _, err := si.Mean(); !errors.Is(err, si.ErrNoUnits) {
+		t.Errorf("Expected ErrNoUnits, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package si
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -15,11 +16,19 @@ type Node interface {
 // IdentNode represents an identifier in the expression (unit symbol)
 type IdentNode struct {
 	Symbol string
+	// Pos is the byte offset of the symbol in the parsed input
+	Pos int
 }
 
-// Eval resolves the identifier to a Unit
+// Eval resolves the identifier to a Unit.
+// An *UnknownUnitError from the context is given the position of the symbol.
 func (n *IdentNode) Eval(ctx Context) (Unit, error) {
-	return ctx.Resolve(n.Symbol)
+	u, err := ctx.Resolve(n.Symbol)
+	var unknown *UnknownUnitError
+	if errors.As(err, &unknown) && unknown.Pos < 0 {
+		unknown.Pos = n.Pos
+	}
+	return u, err
 }
 
 // String returns the symbol name
@@ -47,6 +56,8 @@ type BinaryNode struct {
 	Op    TokenKind
 	Left  Node
 	Right Node
+	// Pos is the byte offset of the operator in the parsed input
+	Pos int
 }
 
 // Eval evaluates both sides of the binary operation and applies the operation
@@ -61,10 +72,23 @@ func (n *BinaryNode) Eval(ctx Context) (Unit, error) {
 		return Unit{}, fmt.Errorf("error evaluating right side: %w", err)
 	}
 
+	// Affine units inside a compound expression denote differences ("°C/s" is K/s)
+	left, right = left.delta(), right.delta()
+
 	switch n.Op {
 	case Multiply:
-		return left.Mul(right), nil
+		if _, ok := tryMulDimensions(left.Dimension, right.Dimension); !ok {
+			return Unit{}, exponentRangeError(n.Pos)
+		}
+		product := left.Mul(right)
+		if isForceTimesLength(left, right) {
+			product.Kind = KindTorque
+		}
+		return product, nil
 	case Divide:
+		if _, ok := tryDivDimensions(left.Dimension, right.Dimension); !ok {
+			return Unit{}, exponentRangeError(n.Pos)
+		}
 		return left.Div(right), nil
 	default:
 		return Unit{}, fmt.Errorf("unsupported binary operation: %v", n.Op)
@@ -84,6 +108,10 @@ func (n *BinaryNode) String() string {
 type PowerNode struct {
 	Base Node
 	Exp  int
+	// Den is the denominator of a fractional exponent Exp/Den. Zero means 1.
+	Den int
+	// Pos is the byte offset of the ^ in the parsed input
+	Pos int
 }
 
 // Eval evaluates the base and raises it to the power
@@ -93,11 +121,27 @@ func (n *PowerNode) Eval(ctx Context) (Unit, error) {
 		return Unit{}, fmt.Errorf("error evaluating base: %w", err)
 	}
 
-	return base.Pow(n.Exp), nil
+	if _, ok := tryPowDimension(base.Dimension, n.Exp, max(n.Den, 1)); !ok {
+		return Unit{}, exponentRangeError(n.Pos)
+	}
+	// Affine units raised to a power denote differences, as in BinaryNode
+	if n.Den != 0 {
+		return base.delta().PowRat(n.Exp, n.Den), nil
+	}
+	return base.delta().Pow(n.Exp), nil
+}
+
+// exponentRangeError reports an operation at offset whose dimension has an
+// exponent beyond the supported range. ParseComplexUnit adds the input.
+func exponentRangeError(offset int) error {
+	return &SyntaxError{Offset: offset, Msg: "dimension exponent out of range"}
 }
 
 // String returns a string representation of the power operation
 func (n *PowerNode) String() string {
+	if n.Den > 1 {
+		return fmt.Sprintf("%s^(%d/%d)", n.Base, n.Exp, n.Den)
+	}
 	return fmt.Sprintf("%s^%d", n.Base, n.Exp)
 }
 
@@ -0,0 +1,158 @@
+package si
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// siDimensionNames, siDimensionSymbols and siUnitSymbols describe the fixed
+// slots of Dimension: the SI base dimensions followed by information and angle
+var (
+	siDimensionNames   = [firstCustomIndex]string{"length", "mass", "time", "current", "temperature", "substance", "luminosity", "information", "angle"}
+	siDimensionSymbols = [firstCustomIndex]string{"L", "M", "T", "I", "Θ", "N", "J", "D", "A"}
+	siUnitSymbols      = [firstCustomIndex]string{"m", "kg", "s", "A", "K", "mol", "cd", "B", "rad"}
+)
+
+// customDimension describes a base dimension added with DefineBaseDimension
+type customDimension struct {
+	name   string
+	symbol string
+	unit   string
+}
+
+// customDimensions holds the custom base dimensions in slot order. The slice is
+// replaced rather than appended to, so formatting reads it without locking.
+var customDimensions atomic.Pointer[[]customDimension]
+
+// customDimensionsMu serializes DefineBaseDimension
+var customDimensionsMu sync.Mutex
+
+// DefineBaseDimension adds a base dimension for quantities the SI does not
+// cover, such as currency, items or events, so that "requests/s" cannot be
+// mixed up with "Hz". The symbol is used by Dimension.String and the unit
+// symbol by the formatter, which writes it without prefixes. The name, symbol
+// and unit symbol must differ from those of every other base dimension. Up to
+// eight base dimensions can be added; they are shared by every registry and
+// should be defined during initialization.
+//
+// The unit itself is not defined; add it to the registries that should parse it.
+//
+// Example:
+//
+//	requests, _ := si.DefineBaseDimension("requests", "R", "req")
+//	si.DefaultRegistry().DefineUnit("req", si.Unit{Value: 1, Dimension: requests}, si.UnitOptions{})
+//	load := si.MustParse("1200 req/s") // 1200 req/s, not 1.2 kHz
+func DefineBaseDimension(name, symbol, unit string) (Dimension, error) {
+	if name == "" || symbol == "" || unit == "" {
+		return Dimension{}, errors.New("base dimension needs a name, a symbol and a unit symbol")
+	}
+
+	customDimensionsMu.Lock()
+	defer customDimensionsMu.Unlock()
+
+	if _, ok := LookupBaseDimension(name); ok {
+		return Dimension{}, fmt.Errorf("base dimension %s is already defined", name)
+	}
+	// A symbol used twice would make ParseDimension and JSON decoding ambiguous,
+	// and a unit symbol used twice would make formatted units ambiguous
+	if _, ok := dimensionIndex(symbol); ok {
+		return Dimension{}, fmt.Errorf("base dimension symbol %s is already in use", symbol)
+	}
+	if _, ok := unitSymbolIndex(unit); ok {
+		return Dimension{}, fmt.Errorf("base unit symbol %s is already in use", unit)
+	}
+
+	var defined []customDimension
+	if current := customDimensions.Load(); current != nil {
+		defined = *current
+	}
+	if len(defined) == maxCustomDimensions {
+		return Dimension{}, fmt.Errorf("cannot define base dimension %s: all %d custom slots are in use", name, maxCustomDimensions)
+	}
+
+	next := append(defined[:len(defined):len(defined)], customDimension{name: name, symbol: symbol, unit: unit})
+	customDimensions.Store(&next)
+
+	return baseDimensionAt(firstCustomIndex + len(defined)), nil
+}
+
+// LookupBaseDimension returns the base dimension with the given name, which is
+// either one of the built-in names ("length", "mass", ..., "information", "angle") or a name
+// passed to DefineBaseDimension.
+func LookupBaseDimension(name string) (Dimension, bool) {
+	for i, n := range siDimensionNames {
+		if n == name {
+			return baseDimensionAt(i), true
+		}
+	}
+	for i, c := range loadCustomDimensions() {
+		if c.name == name {
+			return baseDimensionAt(firstCustomIndex + i), true
+		}
+	}
+	return Dimension{}, false
+}
+
+// loadCustomDimensions returns the custom base dimensions defined so far
+func loadCustomDimensions() []customDimension {
+	if defined := customDimensions.Load(); defined != nil {
+		return *defined
+	}
+	return nil
+}
+
+// dimensionSymbol returns the symbol of base dimension i used by Dimension.String
+func dimensionSymbol(i int) string {
+	if i < firstCustomIndex {
+		return siDimensionSymbols[i]
+	}
+	if defined := loadCustomDimensions(); i-firstCustomIndex < len(defined) {
+		return defined[i-firstCustomIndex].symbol
+	}
+	return fmt.Sprintf("X%d", i) // An exponent in a slot nobody defined
+}
+
+// dimensionIndex returns the slot of the base dimension with the given symbol,
+// the inverse of dimensionSymbol
+func dimensionIndex(symbol string) (int, bool) {
+	for i, s := range siDimensionSymbols {
+		if s == symbol {
+			return i, true
+		}
+	}
+	for i, c := range loadCustomDimensions() {
+		if c.symbol == symbol {
+			return firstCustomIndex + i, true
+		}
+	}
+	return 0, false
+}
+
+// unitSymbolIndex returns the slot of the base dimension with the given unit
+// symbol, the inverse of baseUnitSymbol
+func unitSymbolIndex(unit string) (int, bool) {
+	for i, u := range siUnitSymbols {
+		if u == unit {
+			return i, true
+		}
+	}
+	for i, c := range loadCustomDimensions() {
+		if c.unit == unit {
+			return firstCustomIndex + i, true
+		}
+	}
+	return 0, false
+}
+
+// baseUnitSymbol returns the unit symbol of base dimension i used by the formatter
+func baseUnitSymbol(i int) string {
+	if i < firstCustomIndex {
+		return siUnitSymbols[i]
+	}
+	if defined := loadCustomDimensions(); i-firstCustomIndex < len(defined) {
+		return defined[i-firstCustomIndex].unit
+	}
+	return fmt.Sprintf("x%d", i)
+}
@@ -0,0 +1,86 @@
+package si_test
+
+import (
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestDefineBaseDimension verifies a custom dimension parses, formats and stays apart from Hz
+func TestDefineBaseDimension(t *testing.T) {
+	requests, err := si.DefineBaseDimension("requests", "R", "req")
+	if err != nil {
+		t.Fatalf("DefineBaseDimension error: %v", err)
+	}
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("req", si.Unit{Value: 1, Dimension: requests}, si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+
+	load, err := reg.Parse("1200 req/s")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if load.Dimension == si.Hertz.Dimension {
+		t.Error("Expected requests per second to differ from Hz")
+	}
+	if got := load.String(); got != "1200 req/s" {
+		t.Errorf("String = %q, want %q", got, "1200 req/s")
+	}
+	if got := load.Dimension.String(); got != "T^-1·R" {
+		t.Errorf("Dimension = %q, want %q", got, "T^-1·R")
+	}
+}
+
+// TestDefineBaseDimensionDuplicate verifies a name can only be defined once
+func TestDefineBaseDimensionDuplicate(t *testing.T) {
+	if _, err := si.DefineBaseDimension("pixels", "P", "px"); err != nil {
+		t.Fatalf("DefineBaseDimension error: %v", err)
+	}
+	if _, err := si.DefineBaseDimension("pixels", "P", "px"); err == nil {
+		t.Error("Expected error for a duplicate name")
+	}
+}
+
+// TestDefineBaseDimensionDuplicateSymbol verifies that symbols and unit symbols
+// cannot be reused, which would make parsed and formatted dimensions ambiguous
+func TestDefineBaseDimensionDuplicateSymbol(t *testing.T) {
+	if _, err := si.DefineBaseDimension("widgets", "W", "wdg"); err != nil {
+		t.Fatalf("DefineBaseDimension error: %v", err)
+	}
+	for _, def := range [][3]string{
+		{"lengths", "L", "lng"},     // SI dimension symbol
+		{"gadgets", "W", "gdg"},     // custom dimension symbol
+		{"metres", "Lm", "m"},       // SI unit symbol
+		{"doohickeys", "Dh", "wdg"}, // custom unit symbol
+	} {
+		if _, err := si.DefineBaseDimension(def[0], def[1], def[2]); err == nil {
+			t.Errorf("DefineBaseDimension(%q, %q, %q) succeeded, want error", def[0], def[1], def[2])
+		}
+	}
+}
+
+// TestDefineBaseDimensionSIName verifies the SI names are reserved
+func TestDefineBaseDimensionSIName(t *testing.T) {
+	if _, err := si.DefineBaseDimension("length", "L", "m"); err == nil {
+		t.Error("Expected error for an SI name")
+	}
+}
+
+// TestDefineBaseDimensionEmpty verifies all three names are required
+func TestDefineBaseDimensionEmpty(t *testing.T) {
+	if _, err := si.DefineBaseDimension("events", "", "evt"); err == nil {
+		t.Error("Expected error for an empty symbol")
+	}
+}
+
+// TestLookupBaseDimension verifies the SI dimensions can be found by name
+func TestLookupBaseDimension(t *testing.T) {
+	got, ok := si.LookupBaseDimension("information")
+	if !ok || got != si.Information {
+		t.Errorf("LookupBaseDimension = %v, %v, want %v, true", got, ok, si.Information)
+	}
+	if _, ok := si.LookupBaseDimension("karma"); ok {
+		t.Error("Expected an undefined name not to be found")
+	}
+}
@@ -0,0 +1,346 @@
+package si
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// binaryVersion is the version of the binary format, written as its first byte
+const binaryVersion = 1
+
+// binaryOffset is the flag of an encoded unit with an offset
+const binaryOffset = 1 << 0
+
+// Slot bytes of the binary dimension besides the indices of the SI, information
+// and angle dimensions. Custom base dimensions are written by symbol, since their
+// slots depend on the order a process defines them in.
+const (
+	binaryCustomDimension = 0xfe
+	binaryDenominator     = 0xff
+)
+
+// Errors of malformed binary data
+var (
+	errShortBinary     = errors.New("binary unit data is truncated")
+	errBinaryDimension = errors.New("binary unit data has an invalid dimension")
+)
+
+// AppendBinary implements encoding.BinaryAppender, appending the encoding
+// MarshalBinary returns.
+func (u Unit) AppendBinary(b []byte) ([]byte, error) {
+	b, err := appendBinaryHeader(b, u)
+	if err != nil {
+		return b, err
+	}
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(u.Value)), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with a compact, lossless
+// encoding of the value, dimension, offset and kind; use AppendBlock for series
+// of samples. Version 1 of the format is:
+//
+//	version   1 byte, 1
+//	flags     1 byte, bit 0 set when an offset follows the dimension
+//	kind      1 byte, the QuantityKind
+//	dimension 1 byte count of non-zero slots, then for each slot 1 byte and
+//	          its exponent as a zigzag varint; the byte is 0 to 6 for L, M, T,
+//	          I, Θ, N and J, 7 for information, 8 for angle, 0xfe followed by a
+//	          uvarint length and the symbol for a custom base dimension, and
+//	          0xff for the common denominator of rational exponents
+//	offset    8 bytes, the IEEE 754 bits of Offset, little endian, if flagged
+//	value     8 bytes, the IEEE 754 bits of Value, little endian
+//
+// so 101325 Pa takes 18 bytes. Decoders reject versions they do not know, and
+// custom base dimensions that DefineBaseDimension has not defined.
+//
+// Example:
+//
+//	data, _ := si.Pascals(101325).MarshalBinary() // 18 bytes
+//	var u si.Unit
+//	err := u.UnmarshalBinary(data)
+func (u Unit) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 32))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding what
+// MarshalBinary wrote. Trailing bytes are an error.
+func (u *Unit) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	decoded := r.header()
+	decoded.Value = r.float()
+	if r.err == nil && r.off != len(data) {
+		r.err = fmt.Errorf("binary unit data has %d trailing bytes", len(data)-r.off)
+	}
+	if r.err != nil {
+		return r.err
+	}
+	*u = decoded
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder with the encoding of MarshalBinary
+func (u Unit) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder
+func (u *Unit) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
+// AppendBlock appends a block of units that share a dimension, kind and offset,
+// such as the samples of one sensor, storing those once and each value as the
+// varint of its difference from the previous one. Slowly changing values take
+// a few bytes each instead of the 8 of a float64.
+//
+// A block starts with the header of MarshalBinary up to the offset. The number
+// of units follows as a uvarint, then the first value as 8 bytes and every
+// further value as the zigzag varint of its IEEE 754 bits minus those of the
+// previous value.
+// Units of another dimension or kind than the first are an error, and so are
+// absolute temperatures on another scale. An empty block is ErrNoUnits.
+//
+// Example:
+//
+//	block, _ := si.AppendBlock(nil, samples)
+//	samples, n, err := si.DecodeBlock(nil, block)
+func AppendBlock(b []byte, units []Unit) ([]byte, error) {
+	if len(units) == 0 {
+		return b, ErrNoUnits
+	}
+
+	first := units[0]
+	for _, u := range units[1:] {
+		if u.Dimension != first.Dimension {
+			return b, &DimensionMismatchError{Op: "encode", Left: first.Dimension, Right: u.Dimension}
+		}
+		if u.Kind != first.Kind {
+			return b, &KindMismatchError{Op: "encode", Left: first.Kind, Right: u.Kind}
+		}
+		if u.Offset != first.Offset {
+			return b, errors.New("cannot encode temperatures on different scales in one block")
+		}
+	}
+
+	start := len(b)
+	b, err := appendBinaryHeader(b, first)
+	if err != nil {
+		return b[:start], err
+	}
+	b = binary.AppendUvarint(b, uint64(len(units)))
+	prev := math.Float64bits(first.Value)
+	b = binary.LittleEndian.AppendUint64(b, prev)
+	for _, u := range units[1:] {
+		bits := math.Float64bits(u.Value)
+		b = binary.AppendVarint(b, int64(bits-prev))
+		prev = bits
+	}
+	return b, nil
+}
+
+// DecodeBlock decodes the block at the start of data, appending its units to dst.
+// It returns the number of bytes read, so that consecutive blocks can be decoded
+// from one buffer.
+func DecodeBlock(dst []Unit, data []byte) ([]Unit, int, error) {
+	r := binaryReader{data: data}
+	template := r.header()
+	count := r.uvarint()
+	// Every value takes at least one byte, which bounds the allocation
+	if r.err == nil && (count == 0 || count > uint64(len(data)-r.off)) {
+		r.err = fmt.Errorf("binary block has an invalid count of %d units", count)
+	}
+	if r.err != nil {
+		return dst, 0, r.err
+	}
+
+	start := len(dst)
+	dst = slices.Grow(dst, int(count))
+	bits := math.Float64bits(r.float())
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		if i > 0 {
+			bits += uint64(r.varint())
+		}
+		u := template
+		u.Value = math.Float64frombits(bits)
+		dst = append(dst, u)
+	}
+	if r.err != nil {
+		return dst[:start], 0, r.err
+	}
+	return dst, r.off, nil
+}
+
+// appendBinaryHeader appends the version, flags, kind, dimension and offset of u.
+// It fails for exponents in custom slots that no base dimension is defined for.
+func appendBinaryHeader(b []byte, u Unit) ([]byte, error) {
+	var flags byte
+	if u.Offset != 0 {
+		flags |= binaryOffset
+	}
+	b = append(b, binaryVersion, flags, byte(u.Kind))
+
+	exps := u.Dimension.expand()
+	slots := 0
+	for _, e := range exps {
+		if e != 0 {
+			slots++
+		}
+	}
+	b = append(b, byte(slots))
+	custom := loadCustomDimensions()
+	for i, e := range exps {
+		switch {
+		case e == 0:
+			continue
+		case i == denominatorIndex:
+			b = append(b, binaryDenominator)
+		case i < firstCustomIndex:
+			b = append(b, byte(i))
+		case i-firstCustomIndex < len(custom):
+			symbol := custom[i-firstCustomIndex].symbol
+			b = append(b, binaryCustomDimension)
+			b = binary.AppendUvarint(b, uint64(len(symbol)))
+			b = append(b, symbol...)
+		default:
+			return b, fmt.Errorf("cannot encode base dimension %d, which is not defined", i)
+		}
+		b = binary.AppendVarint(b, int64(e))
+	}
+
+	if flags&binaryOffset != 0 {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(u.Offset))
+	}
+	return b, nil
+}
+
+// binaryReader reads encoded units, keeping the first error
+type binaryReader struct {
+	data []byte
+	off  int
+	err  error
+}
+
+// header reads what appendBinaryHeader wrote, returning a unit without value
+func (r *binaryReader) header() Unit {
+	if version := r.byte(); r.err == nil && version != binaryVersion {
+		r.err = fmt.Errorf("unsupported binary unit format version %d", version)
+	}
+	flags := r.byte()
+	kind := QuantityKind(r.byte())
+	if r.err == nil && int(kind) >= len(kinds) {
+		r.err = fmt.Errorf("binary unit data has unknown kind %d", kind)
+	}
+
+	var exps exponents
+	for slots := int(r.byte()); slots > 0 && r.err == nil; slots-- {
+		i := r.slot()
+		e := r.varint()
+		if r.err == nil && (exps[i] != 0 || e == 0 || e < -maxExponent || e > maxExponent) {
+			r.err = errBinaryDimension
+		}
+		if r.err == nil {
+			exps[i] = int(e)
+		}
+	}
+	dim, ok := exps.pack()
+	if r.err == nil && (!ok || exps.normalized() != exps) {
+		r.err = errBinaryDimension
+	}
+
+	u := Unit{Dimension: dim, Kind: kind}
+	if flags&binaryOffset != 0 {
+		u.Offset = r.float()
+	}
+	return u
+}
+
+// slot reads the slot of one exponent of the dimension, looking up custom base
+// dimensions by symbol
+func (r *binaryReader) slot() int {
+	switch c := r.byte(); {
+	case r.err != nil:
+		return 0
+	case c < firstCustomIndex:
+		return int(c)
+	case c == binaryDenominator:
+		return denominatorIndex
+	case c == binaryCustomDimension:
+		n := r.uvarint()
+		if r.err == nil && n > uint64(len(r.data)-r.off) {
+			r.err = errShortBinary
+		}
+		if r.err != nil {
+			return 0
+		}
+		symbol := string(r.data[r.off : r.off+int(n)])
+		r.off += int(n)
+		i, ok := dimensionIndex(symbol)
+		if !ok || i < firstCustomIndex {
+			r.err = fmt.Errorf("binary unit data has unknown base dimension %q", symbol)
+			return 0
+		}
+		return i
+	default:
+		r.err = errBinaryDimension
+		return 0
+	}
+}
+
+// byte reads one byte
+func (r *binaryReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if r.off >= len(r.data) {
+		r.err = errShortBinary
+		return 0
+	}
+	c := r.data[r.off]
+	r.off++
+	return c
+}
+
+// float reads the little-endian bits of a float64
+func (r *binaryReader) float() float64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data)-r.off < 8 {
+		r.err = errShortBinary
+		return 0
+	}
+	bits := binary.LittleEndian.Uint64(r.data[r.off:])
+	r.off += 8
+	return math.Float64frombits(bits)
+}
+
+// varint reads a zigzag varint
+func (r *binaryReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data[r.off:])
+	if n <= 0 {
+		r.err = errShortBinary
+		return 0
+	}
+	r.off += n
+	return v
+}
+
+// uvarint reads an unsigned varint
+func (r *binaryReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data[r.off:])
+	if n <= 0 {
+		r.err = errShortBinary
+		return 0
+	}
+	r.off += n
+	return v
+}
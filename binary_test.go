@@ -0,0 +1,239 @@
+package si_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// Unit is usable wherever encoders look for binary methods
+var (
+	_ encoding.BinaryMarshaler   = si.Unit{}
+	_ encoding.BinaryAppender    = si.Unit{}
+	_ encoding.BinaryUnmarshaler = (*si.Unit)(nil)
+	_ gob.GobEncoder             = si.Unit{}
+	_ gob.GobDecoder             = (*si.Unit)(nil)
+)
+
+// TestMarshalBinaryRoundTrip verifies that units survive the binary encoding
+// unchanged, including kinds, temperature scales and rational exponents
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	units := []si.Unit{
+		si.Pascals(101325),
+		si.MustParse("21.5 °C"),
+		si.MustParse("5 kN*m"),
+		si.MustParse("3 nV/Hz^(1/2)"),
+		si.Scalar(0.85),
+		si.Meters(math.NaN()),
+		si.Meters(math.Inf(-1)),
+		{},
+	}
+	for _, symbol := range si.NewStandardContext().Symbols() {
+		units = append(units, si.New(1.0/3, symbol))
+	}
+
+	for _, want := range units {
+		data, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%v) error: %v", want, err)
+		}
+		var got si.Unit
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary(%v) error: %v", want, err)
+		}
+		if !sameUnit(got, want) {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%#v)) = %#v", want, got)
+		}
+	}
+}
+
+// TestMarshalBinarySize verifies the documented size of a pressure
+func TestMarshalBinarySize(t *testing.T) {
+	data, _ := si.Pascals(101325).MarshalBinary()
+	if len(data) != 18 {
+		t.Errorf("len(MarshalBinary()) = %d, want 18", len(data))
+	}
+}
+
+// TestUnmarshalBinaryInvalid verifies that malformed data is rejected
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	data, _ := si.MustParse("21.5 °C").MarshalBinary()
+	for n := range len(data) {
+		var u si.Unit
+		if err := u.UnmarshalBinary(data[:n]); err == nil {
+			t.Errorf("UnmarshalBinary of %d of %d bytes succeeded", n, len(data))
+		}
+	}
+
+	var u si.Unit
+	if err := u.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Error("UnmarshalBinary with a trailing byte succeeded")
+	}
+
+	future := bytes.Clone(data)
+	future[0] = 2
+	if err := u.UnmarshalBinary(future); err == nil {
+		t.Error("UnmarshalBinary of version 2 succeeded")
+	}
+}
+
+// TestMarshalBinaryCustomDimension verifies that custom base dimensions are
+// written by symbol, so that a process defining them in another order reads
+// them back, and that undefined symbols are rejected
+func TestMarshalBinaryCustomDimension(t *testing.T) {
+	frames, err := si.DefineBaseDimension("frames", "Fr", "frame")
+	if err != nil {
+		t.Fatalf("DefineBaseDimension error: %v", err)
+	}
+	want := si.Unit{Value: 60, Dimension: frames}.Div(si.Second)
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary error: %v", err)
+	}
+	if !bytes.Contains(data, []byte{0xfe, 2, 'F', 'r'}) {
+		t.Errorf("MarshalBinary() = % x, want the symbol Fr", data)
+	}
+	var got si.Unit
+	if err := got.UnmarshalBinary(data); err != nil || got != want {
+		t.Errorf("UnmarshalBinary() = %v, %v, want %v", got, err, want)
+	}
+
+	unknown := []byte{1, 0, 0, 1, 0xfe, 2, 'Z', 'q', 2, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}
+	if err := got.UnmarshalBinary(unknown); err == nil {
+		t.Error("UnmarshalBinary of an undefined base dimension succeeded")
+	}
+}
+
+// TestMarshalBinaryDenominator verifies the pinned slot byte of the denominator
+func TestMarshalBinaryDenominator(t *testing.T) {
+	data, _ := si.Hertz.Sqrt().MarshalBinary()
+	// T^(-1/2) is stored as T^-1 over a denominator of 2
+	if !bytes.Contains(data, []byte{2, 1, 0xff, 4}) {
+		t.Errorf("MarshalBinary() = % x, want time -1 and denominator 2", data)
+	}
+}
+
+// TestGobRoundTrip verifies that units inside structs survive gob
+func TestGobRoundTrip(t *testing.T) {
+	type reading struct {
+		Sensor   string
+		Pressure si.Unit
+		Torque   si.Unit
+	}
+	want := reading{Sensor: "p1", Pressure: si.Pascals(101325), Torque: si.MustParse("5 kN*m")}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	var got reading
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if got.Sensor != want.Sensor || !sameUnit(got.Pressure, want.Pressure) || !sameUnit(got.Torque, want.Torque) {
+		t.Errorf("gob round trip = %#v, want %#v", got, want)
+	}
+}
+
+// TestBlockRoundTrip verifies that a block of samples decodes unchanged and is
+// smaller than the samples as float64s
+func TestBlockRoundTrip(t *testing.T) {
+	samples := make([]si.Unit, 1000)
+	for i := range samples {
+		samples[i] = si.Pascals(101325 + float64(i%50)*0.25)
+	}
+
+	block, err := si.AppendBlock(nil, samples)
+	if err != nil {
+		t.Fatalf("AppendBlock() error: %v", err)
+	}
+	if len(block) >= len(samples)*8 {
+		t.Errorf("block of %d samples takes %d bytes", len(samples), len(block))
+	}
+
+	got, n, err := si.DecodeBlock(nil, block)
+	if err != nil {
+		t.Fatalf("DecodeBlock() error: %v", err)
+	}
+	if n != len(block) || len(got) != len(samples) {
+		t.Fatalf("DecodeBlock() = %d units from %d bytes, want %d from %d", len(got), n, len(samples), len(block))
+	}
+	for i := range samples {
+		if !sameUnit(got[i], samples[i]) {
+			t.Fatalf("DecodeBlock()[%d] = %#v, want %#v", i, got[i], samples[i])
+		}
+	}
+}
+
+// TestBlockConsecutive verifies that consecutive blocks decode from one buffer
+func TestBlockConsecutive(t *testing.T) {
+	temperatures := []si.Unit{si.MustParse("21.5 °C"), si.MustParse("-3 °C"), si.MustParse("22 °C")}
+	speeds := []si.Unit{si.MustParse("3 rad/s"), si.MustParse("-2 rad/s")}
+
+	buf, err := si.AppendBlock(nil, temperatures)
+	if err != nil {
+		t.Fatalf("AppendBlock(temperatures) error: %v", err)
+	}
+	if buf, err = si.AppendBlock(buf, speeds); err != nil {
+		t.Fatalf("AppendBlock(speeds) error: %v", err)
+	}
+
+	var got []si.Unit
+	for len(buf) > 0 {
+		var n int
+		if got, n, err = si.DecodeBlock(got, buf); err != nil {
+			t.Fatalf("DecodeBlock() error: %v", err)
+		}
+		buf = buf[n:]
+	}
+
+	want := append(temperatures, speeds...)
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d units, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !sameUnit(got[i], want[i]) {
+			t.Errorf("unit %d = %#v, want %#v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestAppendBlockMismatch verifies that a block holds one dimension, kind and scale
+func TestAppendBlockMismatch(t *testing.T) {
+	var mismatch *si.DimensionMismatchError
+	if _, err := si.AppendBlock(nil, []si.Unit{si.Meters(1), si.Seconds(1)}); !errors.As(err, &mismatch) {
+		t.Errorf("AppendBlock(m, s) error = %v, want DimensionMismatchError", err)
+	}
+
+	var kinds *si.KindMismatchError
+	if _, err := si.AppendBlock(nil, []si.Unit{si.MustParse("1 J"), si.MustParse("1 N*m")}); !errors.As(err, &kinds) {
+		t.Errorf("AppendBlock(J, N*m) error = %v, want KindMismatchError", err)
+	}
+
+	if _, err := si.AppendBlock(nil, []si.Unit{si.MustParse("1 °C"), si.MustParse("1 °F")}); err == nil {
+		t.Error("AppendBlock(°C, °F) succeeded, want error")
+	}
+	if _, err := si.AppendBlock(nil, nil); !errors.Is(err, si.ErrNoUnits) {
+		t.Errorf("AppendBlock(nil) error = %v, want ErrNoUnits", err)
+	}
+}
+
+// TestDecodeBlockTruncated verifies that a cut block is an error and leaves dst alone
+func TestDecodeBlockTruncated(t *testing.T) {
+	block, _ := si.AppendBlock(nil, []si.Unit{si.Meters(1), si.Meters(2), si.Meters(3.5)})
+	dst := []si.Unit{si.Seconds(1)}
+	for n := range len(block) {
+		got, _, err := si.DecodeBlock(dst, block[:n])
+		if err == nil {
+			t.Errorf("DecodeBlock of %d of %d bytes succeeded", n, len(block))
+		}
+		if len(got) != 1 {
+			t.Errorf("DecodeBlock of %d bytes left %d units in dst, want 1", n, len(got))
+		}
+	}
+}
@@ -1,27 +1,42 @@
 package si
 
 import (
-	"fmt"
 	"math"
-	"sort"
-	"strings"
 )
 
-// StandardContext implements the Context interface with standard SI units and prefixes
-type StandardContext struct {
-	baseUnits      map[string]Unit
-	derivedUnits   map[string]Unit
-	prefixes       map[string]float64
-	sortedPrefixes []string
+// StandardContext is a Registry preloaded with the SI base units, common
+// derived units and the SI and binary prefixes.
+// It is kept as a named alias so existing callers of NewStandardContext keep working.
+type StandardContext = Registry
+
+// ContextOptions selects variations of the standard units for NewContext
+type ContextOptions struct {
+	// AngleDimension gives angles the Angle dimension instead of treating them as
+	// dimensionless, so that rad/s is kept apart from Hz and an angle cannot be
+	// added to a plain number. Use Unit.DropAngle to return to SI semantics.
+	AngleDimension bool
+	// DimensionlessInformation registers bytes and bits as plain numbers, as
+	// the SI does, instead of giving them the Information dimension. 1 kB is
+	// then 1000 and 3 MB/s formats as 3 MHz.
+	DimensionlessInformation bool
 }
 
-// NewStandardContext creates a new context with standard SI units and prefixes
+// NewStandardContext creates a new registry with standard SI units and prefixes.
+// The returned registry is independent of the default registry and can be extended
+// with DefineUnit, DefinePrefix and DefineAlias.
 func NewStandardContext() *StandardContext {
-	ctx := &StandardContext{
-		baseUnits:    make(map[string]Unit),
-		derivedUnits: make(map[string]Unit),
-		prefixes:     make(map[string]float64),
-	}
+	return NewContext(ContextOptions{})
+}
+
+// NewContext creates a new registry with standard SI units and prefixes,
+// varied by opts.
+//
+// Example:
+//
+//	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+//	speed, _ := ctx.Parse("1500 rpm") // 157.0796326794897 rad/s
+func NewContext(opts ContextOptions) *StandardContext {
+	ctx := NewRegistry()
 
 	// Register SI base units
 	ctx.registerBaseUnits()
@@ -29,173 +44,208 @@ func NewStandardContext() *StandardContext {
 	// Register SI derived units
 	ctx.registerDerivedUnits()
 
+	// Register angles, dimensionless as in the SI unless asked otherwise
+	angle := Dimensionless
+	if opts.AngleDimension {
+		angle = Angle
+	}
+	ctx.registerAngleUnits(angle)
+
+	// Register bytes and bits, with a dimension of their own unless asked otherwise
+	information := Information
+	if opts.DimensionlessInformation {
+		information = Dimensionless
+	}
+	ctx.registerInformationUnits(information)
+
 	// Register SI prefixes
 	ctx.registerPrefixes()
 
-	// Sort prefixes by length for proper matching
-	ctx.sortPrefixes()
-
 	return ctx
 }
 
+// mustDefineUnit registers a built-in unit and panics on failure.
+// The built-in tables are static, so a failure here is a programming error.
+func (ctx *Registry) mustDefineUnit(symbol string, u Unit, opts UnitOptions) {
+	if err := ctx.DefineUnit(symbol, u, opts); err != nil {
+		panic(err)
+	}
+}
+
+// mustDefineAlias registers a built-in alias and panics on failure.
+func (ctx *Registry) mustDefineAlias(alias, target string) {
+	if err := ctx.DefineAlias(alias, target); err != nil {
+		panic(err)
+	}
+}
+
 // registerBaseUnits registers the 7 SI base units
-func (ctx *StandardContext) registerBaseUnits() {
+func (ctx *Registry) registerBaseUnits() {
 	// Length, Mass, Time, Current, Temperature, Substance, Luminosity
-	ctx.baseUnits["m"] = Unit{1, Dimension{1, 0, 0, 0, 0, 0, 0}}
-	ctx.baseUnits["kg"] = Unit{1, Dimension{0, 1, 0, 0, 0, 0, 0}}
-	ctx.baseUnits["s"] = Unit{1, Dimension{0, 0, 1, 0, 0, 0, 0}}
-	ctx.baseUnits["A"] = Unit{1, Dimension{0, 0, 0, 1, 0, 0, 0}}
-	ctx.baseUnits["K"] = Unit{1, Dimension{0, 0, 0, 0, 1, 0, 0}}
-	ctx.baseUnits["mol"] = Unit{1, Dimension{0, 0, 0, 0, 0, 1, 0}}
-	ctx.baseUnits["cd"] = Unit{1, Dimension{0, 0, 0, 0, 0, 0, 1}}
+	ctx.mustDefineUnit("m", Unit{Value: 1, Dimension: NewDimension(1, 0, 0, 0, 0, 0, 0)}, UnitOptions{})
+	ctx.mustDefineUnit("s", Unit{Value: 1, Dimension: NewDimension(0, 0, 1, 0, 0, 0, 0)}, UnitOptions{})
+	ctx.mustDefineUnit("A", Unit{Value: 1, Dimension: NewDimension(0, 0, 0, 1, 0, 0, 0)}, UnitOptions{})
+	ctx.mustDefineUnit("K", Unit{Value: 1, Dimension: NewDimension(0, 0, 0, 0, 1, 0, 0)}, UnitOptions{})
+	ctx.mustDefineUnit("mol", Unit{Value: 1, Dimension: NewDimension(0, 0, 0, 0, 0, 1, 0)}, UnitOptions{})
+	ctx.mustDefineUnit("cd", Unit{Value: 1, Dimension: NewDimension(0, 0, 0, 0, 0, 0, 1)}, UnitOptions{})
+
+	// The kilogram already carries a prefix, so prefixes attach to the gram instead
+	ctx.mustDefineUnit("kg", Unit{Value: 1, Dimension: NewDimension(0, 1, 0, 0, 0, 0, 0)}, UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineUnit("g", Unit{Value: 0.001, Dimension: NewDimension(0, 1, 0, 0, 0, 0, 0)}, UnitOptions{})
+
+	// The tonne only takes the prefixes in use, so that "ft" or "pt" is never read as a tiny tonne
+	ctx.mustDefineUnit("t", Unit{Value: 1000, Dimension: NewDimension(0, 1, 0, 0, 0, 0, 0)}, UnitOptions{Only: []string{"k", "M", "G"}})
 }
 
 // registerDerivedUnits registers common SI derived units
-func (ctx *StandardContext) registerDerivedUnits() {
+func (ctx *Registry) registerDerivedUnits() {
 	// Newton: kg·m/s²
-	newton := ctx.baseUnits["kg"].Mul(ctx.baseUnits["m"]).Div(ctx.baseUnits["s"].Pow(2))
-	ctx.derivedUnits["N"] = newton
+	ctx.mustDefineUnit("N", Newton, UnitOptions{})
 
 	// Joule: N·m
-	joule := newton.Mul(ctx.baseUnits["m"])
-	ctx.derivedUnits["J"] = joule
+	ctx.mustDefineUnit("J", ofKind(Joule, KindEnergy), UnitOptions{})
 
 	// Watt: J/s
-	watt := joule.Div(ctx.baseUnits["s"])
-	ctx.derivedUnits["W"] = watt
+	ctx.mustDefineUnit("W", Watt, UnitOptions{})
 
 	// Pascal: N/m²
-	pascal := newton.Div(ctx.baseUnits["m"].Pow(2))
-	ctx.derivedUnits["Pa"] = pascal
+	ctx.mustDefineUnit("Pa", ofKind(Pascal, KindPressure), UnitOptions{})
 
-	// PSI: Pound per square inch (1 psi = 6894.76 Pa)
-	psi := Unit{6894.76, pascal.Dimension}
-	ctx.derivedUnits["psi"] = psi
+	// PSI: Pound-force per square inch (1 psi = 1 lbf/in² = 6894.757293168361 Pa)
+	ctx.mustDefineUnit("psi", Unit{Value: psiPascals, Dimension: Pascal.Dimension, Kind: KindPressure}, UnitOptions{Prefixes: PrefixNone})
 
 	// Hertz: 1/s
-	hertz := Unit{1, Dimension{0, 0, -1, 0, 0, 0, 0}}
-	ctx.derivedUnits["Hz"] = hertz
+	ctx.mustDefineUnit("Hz", ofKind(Hertz, KindFrequency), UnitOptions{})
 
 	// Coulomb: A·s
-	coulomb := ctx.baseUnits["A"].Mul(ctx.baseUnits["s"])
-	ctx.derivedUnits["C"] = coulomb
+	ctx.mustDefineUnit("C", Coulomb, UnitOptions{})
 
 	// Volt: W/A
-	volt := watt.Div(ctx.baseUnits["A"])
-	ctx.derivedUnits["V"] = volt
-
-	// Other units with conversion factors
-	ctx.derivedUnits["h"] = Unit{3600, Dimension{0, 0, 1, 0, 0, 0, 0}}  // hour
-	ctx.derivedUnits["min"] = Unit{60, Dimension{0, 0, 1, 0, 0, 0, 0}}  // minute
-	ctx.derivedUnits["d"] = Unit{86400, Dimension{0, 0, 1, 0, 0, 0, 0}} // day
+	ctx.mustDefineUnit("V", Volt, UnitOptions{})
+
+	// Electromagnetic units
+	ctx.mustDefineUnit("Ω", Ohm, UnitOptions{})
+	ctx.mustDefineAlias("ohm", "Ω")
+	ctx.mustDefineUnit("S", Siemens, UnitOptions{})
+	ctx.mustDefineUnit("F", Farad, UnitOptions{})
+	ctx.mustDefineUnit("H", Henry, UnitOptions{})
+	ctx.mustDefineUnit("Wb", Weber, UnitOptions{})
+	ctx.mustDefineUnit("T", Tesla, UnitOptions{})
+
+	// Radiation and catalysis; Bq shares its dimension with Hz, Gy with Sv and
+	// kat with mol/s, so their kinds keep them apart
+	ctx.mustDefineUnit("Bq", ofKind(Hertz, KindActivity), UnitOptions{})
+	ctx.mustDefineUnit("Gy", ofKind(Gray, KindAbsorbedDose), UnitOptions{})
+	ctx.mustDefineUnit("Sv", ofKind(Gray, KindEquivalentDose), UnitOptions{})
+	ctx.mustDefineUnit("kat", ofKind(Katal, KindCatalyticActivity), UnitOptions{})
+
+	// Other units with conversion factors; prefixing them ("kh", "mmin") is never intended
+	ctx.mustDefineUnit("h", Unit{Value: 3600, Dimension: TimeDim}, UnitOptions{Prefixes: PrefixNone})  // hour
+	ctx.mustDefineUnit("min", Unit{Value: 60, Dimension: TimeDim}, UnitOptions{Prefixes: PrefixNone})  // minute
+	ctx.mustDefineUnit("d", Unit{Value: 86400, Dimension: TimeDim}, UnitOptions{Prefixes: PrefixNone}) // day
+
+	// Non-SI units accepted for use with the SI
+	ctx.mustDefineUnit("L", Meter.Pow(3).Mul(Scalar(1e-3)), UnitOptions{}) // litre
+	ctx.mustDefineAlias("l", "L")
+	ctx.mustDefineUnit("ha", Meter.Pow(2).Mul(Scalar(1e4)), UnitOptions{Prefixes: PrefixNone}) // hectare
+	ctx.mustDefineUnit("bar", ofKind(Pascal.Mul(Scalar(1e5)), KindPressure), UnitOptions{})
+	ctx.mustDefineUnit("eV", ofKind(Joule.Mul(Scalar(1.602176634e-19)), KindEnergy), UnitOptions{}) // electronvolt, exact since 2019
+	ctx.mustDefineUnit("Da", Kilogram.Mul(Scalar(1.66053906892e-27)), UnitOptions{})                // dalton, CODATA 2022
+	ctx.mustDefineUnit("au", Meter.Mul(Scalar(149597870700)), UnitOptions{Prefixes: PrefixNone})    // astronomical unit
+	ctx.mustDefineUnit("Å", Meter.Mul(Scalar(1e-10)), UnitOptions{Prefixes: PrefixNone})            // ångström
+	ctx.mustDefineAlias("angstrom", "Å")
+	ctx.mustDefineUnit("Wh", ofKind(Joule.Mul(Scalar(3600)), KindEnergy), UnitOptions{}) // watt-hour, as in kWh
+
+	// Affine temperature scales; prefixing would scale the offset too, so it is forbidden
+	ctx.mustDefineUnit("°C", degreeCelsius, UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineUnit("°F", degreeFahrenheit, UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineAlias("degC", "°C")
+	ctx.mustDefineAlias("degF", "°F")
+}
 
-	// Information units
-	ctx.derivedUnits["B"] = Unit{1, Dimension{0, 0, 0, 0, 0, 0, 0}}  // byte
-	ctx.derivedUnits["iB"] = Unit{1, Dimension{0, 0, 0, 0, 0, 0, 0}} // byte for binary prefixes
+// informationPrefixes are the prefixes bytes and bits take. Submultiples are
+// left out, so that "dB" is never read as a tenth of a byte.
+var informationPrefixes = []string{
+	"k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q",
+	"Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi",
 }
 
-// registerPrefixes registers SI and binary prefixes
-func (ctx *StandardContext) registerPrefixes() {
-	// SI prefixes
-	ctx.prefixes["Y"] = 1e24
-	ctx.prefixes["Z"] = 1e21
-	ctx.prefixes["E"] = 1e18
-	ctx.prefixes["P"] = 1e15
-	ctx.prefixes["T"] = 1e12
-	ctx.prefixes["G"] = 1e9
-	ctx.prefixes["M"] = 1e6
-	ctx.prefixes["k"] = 1e3
-	ctx.prefixes["h"] = 1e2
-	ctx.prefixes["da"] = 1e1
-	ctx.prefixes[""] = 1
-	ctx.prefixes["d"] = 1e-1
-	ctx.prefixes["c"] = 1e-2
-	ctx.prefixes["m"] = 1e-3
-	ctx.prefixes["u"] = 1e-6
-	ctx.prefixes["μ"] = 1e-6
-	ctx.prefixes["µ"] = 1e-6
-	ctx.prefixes["n"] = 1e-9
-	ctx.prefixes["p"] = 1e-12
-	ctx.prefixes["f"] = 1e-15
-	ctx.prefixes["a"] = 1e-18
-	ctx.prefixes["z"] = 1e-21
-	ctx.prefixes["y"] = 1e-24
+// registerInformationUnits registers the byte and the bit with information as
+// the dimension of the byte
+func (ctx *Registry) registerInformationUnits(information Dimension) {
+	opts := UnitOptions{Prefixes: PrefixAll, Only: informationPrefixes}
+	ctx.mustDefineUnit("B", Unit{Value: 1, Dimension: information}, opts)       // byte
+	ctx.mustDefineUnit("bit", Unit{Value: 0.125, Dimension: information}, opts) // 1/8 byte
 
-	// Binary prefixes
-	ctx.prefixes["Ki"] = math.Pow(2, 10)
-	ctx.prefixes["Mi"] = math.Pow(2, 20)
-	ctx.prefixes["Gi"] = math.Pow(2, 30)
-	ctx.prefixes["Ti"] = math.Pow(2, 40)
-	ctx.prefixes["Pi"] = math.Pow(2, 50)
-	ctx.prefixes["Ei"] = math.Pow(2, 60)
+	// Earlier versions spelled the byte after binary prefixes as iB
+	ctx.mustDefineUnit("iB", Unit{Value: 1, Dimension: information}, UnitOptions{Prefixes: PrefixNone})
 }
 
-// sortPrefixes sorts prefixes by length for proper matching
-func (ctx *StandardContext) sortPrefixes() {
-	ctx.sortedPrefixes = make([]string, 0, len(ctx.prefixes))
-	for p := range ctx.prefixes {
-		ctx.sortedPrefixes = append(ctx.sortedPrefixes, p)
-	}
-	sort.Slice(ctx.sortedPrefixes, func(i, j int) bool {
-		return len(ctx.sortedPrefixes[i]) > len(ctx.sortedPrefixes[j])
-	})
+// registerAngleUnits registers plane and solid angle units with angle as the
+// dimension of the radian, along with the photometric units built on the steradian
+func (ctx *Registry) registerAngleUnits(angle Dimension) {
+	// Units built on the radian are angles, sr a solid angle and rpm an angular velocity
+	radian := Unit{Value: 1, Dimension: angle, Kind: KindAngle}
+	ctx.mustDefineUnit("rad", radian, UnitOptions{})
+	steradian := ofKind(radian.Pow(2), KindSolidAngle)
+	ctx.mustDefineUnit("sr", steradian, UnitOptions{})
+
+	// Photometric units are defined per steradian, so they follow the angle dimension
+	lumen := Candela.Mul(steradian)
+	ctx.mustDefineUnit("lm", lumen, UnitOptions{})
+	ctx.mustDefineUnit("lx", ofKind(lumen.Div(Meter.Pow(2)), KindIlluminance), UnitOptions{}) // lux
+
+	// Prefixing these ("kdeg", "marcmin") is never intended
+	ctx.mustDefineUnit("°", radian.Mul(Scalar(math.Pi/180)), UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineAlias("deg", "°")
+	ctx.mustDefineUnit("′", radian.Mul(Scalar(math.Pi/(180*60))), UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineAlias("arcmin", "′")
+	ctx.mustDefineUnit("″", radian.Mul(Scalar(math.Pi/(180*3600))), UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineAlias("arcsec", "″")
+	ctx.mustDefineUnit("grad", radian.Mul(Scalar(math.Pi/200)), UnitOptions{Prefixes: PrefixNone})
+	ctx.mustDefineUnit("rev", radian.Mul(Scalar(2*math.Pi)), UnitOptions{Prefixes: PrefixNone})
+
+	// Rotational speed in revolutions per minute
+	ctx.mustDefineUnit("rpm", radian.Mul(Scalar(2*math.Pi/60)).Div(Second), UnitOptions{Prefixes: PrefixNone})
 }
 
-// Resolve implements the Context interface
-func (ctx *StandardContext) Resolve(symbol string) (Unit, error) {
-	// Handle special case for dimensionless unit
-	if symbol == "1" || symbol == "" {
-		return Unit{1, Dimension{}}, nil
-	}
-
-	// Handle gram special case
-	if symbol == "g" {
-		return Unit{0.001, Dimension{0, 1, 0, 0, 0, 0, 0}}, nil
-	}
+// ofKind returns u tagged with kind, for definitions known to fit the kind
+func ofKind(u Unit, kind QuantityKind) Unit {
+	u.Kind = kind
+	return u
+}
 
-	// Try to match as direct unit
-	if unit, ok := ctx.baseUnits[symbol]; ok {
-		return unit, nil
-	}
-	if unit, ok := ctx.derivedUnits[symbol]; ok {
-		return unit, nil
+// registerPrefixes registers SI and binary prefixes
+func (ctx *Registry) registerPrefixes() {
+	decimal := []struct {
+		symbol string
+		factor float64
+	}{
+		{"Q", 1e30}, {"R", 1e27}, {"Y", 1e24}, {"Z", 1e21}, {"E", 1e18}, {"P", 1e15},
+		{"T", 1e12}, {"G", 1e9}, {"M", 1e6}, {"k", 1e3},
+		{"h", 1e2}, {"da", 1e1}, {"d", 1e-1}, {"c", 1e-2},
+		{"m", 1e-3}, {"u", 1e-6}, {"μ", 1e-6}, {"µ", 1e-6},
+		{"n", 1e-9}, {"p", 1e-12}, {"f", 1e-15}, {"a", 1e-18},
+		{"z", 1e-21}, {"y", 1e-24}, {"r", 1e-27}, {"q", 1e-30},
 	}
-
-	// Handle binary prefixes (KiB, MiB, etc.)
-	if strings.HasSuffix(symbol, "iB") {
-		for _, prefix := range []string{"K", "M", "G", "T", "P", "E"} {
-			if strings.HasPrefix(symbol, prefix) && symbol == prefix+"iB" {
-				return Unit{ctx.prefixes[prefix+"i"], Dimension{}}, nil
-			}
+	for _, p := range decimal {
+		if err := ctx.DefinePrefix(p.symbol, p.factor); err != nil {
+			panic(err)
 		}
 	}
 
-	// Try with prefixes
-	for _, prefix := range ctx.sortedPrefixes {
-		if prefix == "" {
-			continue
-		}
-
-		if !strings.HasPrefix(symbol, prefix) {
-			continue
-		}
-
-		suffix := symbol[len(prefix):]
-
-		// Try base units with this prefix
-		if unit, ok := ctx.baseUnits[suffix]; ok {
-			scaledUnit := unit
-			scaledUnit.Value *= ctx.prefixes[prefix]
-			return scaledUnit, nil
-		}
-
-		// Try derived units with this prefix
-		if unit, ok := ctx.derivedUnits[suffix]; ok {
-			scaledUnit := unit
-			scaledUnit.Value *= ctx.prefixes[prefix]
-			return scaledUnit, nil
+	// Binary prefixes
+	binary := []struct {
+		symbol string
+		factor float64
+	}{
+		{"Ki", math.Pow(2, 10)}, {"Mi", math.Pow(2, 20)}, {"Gi", math.Pow(2, 30)},
+		{"Ti", math.Pow(2, 40)}, {"Pi", math.Pow(2, 50)}, {"Ei", math.Pow(2, 60)},
+		{"Zi", math.Pow(2, 70)}, {"Yi", math.Pow(2, 80)},
+	}
+	for _, p := range binary {
+		if err := ctx.DefineBinaryPrefix(p.symbol, p.factor); err != nil {
+			panic(err)
 		}
 	}
-
-	return Unit{}, fmt.Errorf("unrecognized unit: %s", symbol)
 }
@@ -0,0 +1,528 @@
+package si
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// numSIDimensions is the number of SI base dimensions.
+const numSIDimensions = 7
+
+// informationIndex is the slot of Dimension holding information, which the SI
+// treats as dimensionless but which is tracked so that bytes are not plain numbers.
+const informationIndex = numSIDimensions
+
+// angleIndex is the slot of Dimension holding plane angle, which is only used by
+// registries created with ContextOptions.AngleDimension
+const angleIndex = informationIndex + 1
+
+// firstCustomIndex is the first slot of Dimension available to DefineBaseDimension
+const firstCustomIndex = angleIndex + 1
+
+// maxCustomDimensions is the number of base dimensions DefineBaseDimension can add
+const maxCustomDimensions = 8
+
+// numBaseDimensions is the number of base dimensions tracked by Dimension.
+const numBaseDimensions = firstCustomIndex + maxCustomDimensions
+
+// denominatorIndex is the slot of exponents holding the common denominator of
+// all exponents. Zero means 1, so integer exponents keep their literal form.
+const denominatorIndex = numBaseDimensions
+
+// inlineDimensions is the number of base dimensions stored inline in a
+// Dimension: the SI base dimensions and information
+const inlineDimensions = informationIndex + 1
+
+// Dimension represents the exponents of the base dimensions of a quantity: the
+// 7 SI base dimensions, information, angle and the base dimensions added with
+// DefineBaseDimension, in that order. The index positions used by Exponent and
+// NewDimension are: [Length, Mass, Time, Current, Temperature, Substance,
+// Luminosity, Information, Angle, ...]. The zero value is dimensionless.
+//
+// Exponents may be rational, as in the √Hz of a noise density. When the
+// exponents of a dimension are integers from -128 to 127 of the SI base
+// dimensions and information, as for all ordinary quantities, they are packed
+// into a single word, so the dimension is copied, compared and multiplied
+// without indirection. Other dimensions, with angles, custom base dimensions,
+// rational or large exponents, keep their exponents in a shared extension that
+// is interned, so == compares dimensions correctly.
+// Numerators and denominators range up to ±2147483647. Parse and the decoders
+// report exponents beyond that as errors, while Mul, Div and Pow panic.
+type Dimension struct {
+	// exps packs the exponents as signed bytes, base dimension i in byte i,
+	// when ext is nil
+	exps uint64
+	// ext holds the exponents of dimensions that do not fit into exps
+	ext *dimensionExt
+}
+
+// dimensionExt holds the exponents of a Dimension that cannot be packed.
+// Values are interned by internExt and never modified.
+type dimensionExt struct {
+	// exps holds the numerator of every base dimension followed by the common
+	// denominator, as in exponents
+	exps [numBaseDimensions + 1]int32
+}
+
+// maxExponent bounds the numerators and denominators of exponents. Products of
+// two of them, as computed by the exponent arithmetic, cannot overflow an int.
+const maxExponent = 1<<31 - 1
+
+// laneSigns has the sign bit of every inline exponent set
+const laneSigns = 0x8080808080808080
+
+// dimensionExts maps each dimensionExt in use to its interned copy
+var dimensionExts sync.Map
+
+// internExt returns the shared copy of e, so that equal extensions are the same pointer
+func internExt(e dimensionExt) *dimensionExt {
+	if p, ok := dimensionExts.Load(e); ok {
+		return p.(*dimensionExt)
+	}
+	p, _ := dimensionExts.LoadOrStore(e, &e)
+	return p.(*dimensionExt)
+}
+
+// exponents is the expanded form of a Dimension used by the slow paths: the
+// numerator of every base dimension followed by the common denominator, which
+// is zero for integer exponents
+type exponents [numBaseDimensions + 1]int
+
+// errExponentRange is the panic of arithmetic that leaves the range of an exponent
+const errExponentRange = "si: dimension exponent out of range"
+
+// NewDimension returns the dimension with the given integer exponents, in the
+// slot order of Dimension. Missing exponents are zero. It panics if there are
+// more exponents than base dimensions or one is out of range.
+//
+// Example:
+//
+//	force := si.NewDimension(1, 1, -2) // L·M·T^-2, the dimension of N
+func NewDimension(exps ...int) Dimension {
+	if len(exps) > numBaseDimensions {
+		panic("si: NewDimension with more exponents than base dimensions")
+	}
+	var e exponents
+	copy(e[:], exps)
+	return e.dimension()
+}
+
+// MustParseDimension is like ParseDimension but panics on error. It is used by
+// GoString for dimensions NewDimension cannot express.
+func MustParseDimension(s string) Dimension {
+	d, err := ParseDimension(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// baseDimensionAt returns the dimension of base dimension i
+func baseDimensionAt(i int) Dimension {
+	e, _ := baseExponents(i, 1, 1)
+	return e.dimension()
+}
+
+// numerator returns the stored numerator of base dimension i
+func (d Dimension) numerator(i int) int {
+	if d.ext != nil {
+		return int(d.ext.exps[i])
+	}
+	if i < inlineDimensions {
+		return int(int8(d.exps >> (8 * i)))
+	}
+	return 0
+}
+
+// expand returns the exponents of d
+func (d Dimension) expand() exponents {
+	var e exponents
+	if d.ext != nil {
+		for i, x := range d.ext.exps {
+			e[i] = int(x)
+		}
+		return e
+	}
+	for i := 0; i < inlineDimensions; i++ {
+		e[i] = d.numerator(i)
+	}
+	return e
+}
+
+// dimension normalizes e and packs it into a Dimension. It panics if an
+// exponent exceeds maxExponent.
+func (e exponents) dimension() Dimension {
+	d, ok := e.pack()
+	if !ok {
+		panic(errExponentRange)
+	}
+	return d
+}
+
+// pack normalizes e and packs it into a Dimension, reporting false if an
+// exponent exceeds maxExponent. The parser and the decoders use it to reject
+// input instead of panicking.
+func (e exponents) pack() (Dimension, bool) {
+	e = e.normalized()
+	inline := e[denominatorIndex] == 0
+	for i, x := range e {
+		if x < -maxExponent || x > maxExponent {
+			return Dimension{}, false
+		}
+		if x != int(int8(x)) || i >= inlineDimensions && x != 0 {
+			inline = false
+		}
+	}
+
+	var d Dimension
+	if !inline {
+		var ext dimensionExt
+		for i, x := range e {
+			ext.exps[i] = int32(x)
+		}
+		d.ext = internExt(ext)
+		return d, true
+	}
+	for i := 0; i < inlineDimensions; i++ {
+		d.exps |= uint64(uint8(e[i])) << (8 * i)
+	}
+	return d, true
+}
+
+// mul returns the exponents of a product, i.e. the sum of exponents
+func (e exponents) mul(f exponents) exponents {
+	de, df := max(e[denominatorIndex], 1), max(f[denominatorIndex], 1)
+	var product exponents
+	for i := 0; i < numBaseDimensions; i++ {
+		product[i] = e[i]*df + f[i]*de
+	}
+	product[denominatorIndex] = de * df
+	return product.normalized()
+}
+
+// withoutAngle returns d with the angle exponent removed
+func (d Dimension) withoutAngle() Dimension {
+	if d.ext == nil {
+		return d // Dimensions with an angle exponent have an extension
+	}
+	e := d.expand()
+	e[angleIndex] = 0
+	return e.dimension()
+}
+
+// denominator returns the common denominator of the exponents
+func (d Dimension) denominator() int {
+	if d.ext == nil || d.ext.exps[denominatorIndex] == 0 {
+		return 1
+	}
+	return int(d.ext.exps[denominatorIndex])
+}
+
+// Exponent returns the exponent of base dimension i as a fraction in lowest terms.
+// The denominator is 1 for integer exponents.
+//
+// Example:
+//
+//	num, den := Hertz.Sqrt().Dimension.Exponent(2) // -1, 2
+func (d Dimension) Exponent(i int) (num, den int) {
+	num, den = d.numerator(i), d.denominator()
+	g := gcd(abs(num), den)
+	return num / g, den / g
+}
+
+// IsInteger reports whether every exponent is a whole number.
+func (d Dimension) IsInteger() bool {
+	return d.denominator() == 1
+}
+
+// String writes the dimension in terms of the base dimension symbols, e.g. "L·M·T^-2".
+// Fractional exponents are written as "T^(-1/2)". Dimensionless is "1".
+func (d Dimension) String() string {
+	var parts []string
+	for i := 0; i < numBaseDimensions; i++ {
+		num, den := d.Exponent(i)
+		switch {
+		case num == 0:
+			continue
+		case den != 1:
+			parts = append(parts, dimensionSymbol(i)+"^("+strconv.Itoa(num)+"/"+strconv.Itoa(den)+")")
+		case num == 1:
+			parts = append(parts, dimensionSymbol(i))
+		default:
+			parts = append(parts, dimensionSymbol(i)+"^"+strconv.Itoa(num))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "1" // Dimensionless
+	}
+	return strings.Join(parts, "·")
+}
+
+// ParseDimension reads a dimension written by Dimension.String, such as
+// "L·M·T^-2" or "T^(-1/2)". Factors may also be separated by "*", and "1" is
+// dimensionless. Custom base dimensions are looked up by their symbol.
+//
+// Example:
+//
+//	d, _ := ParseDimension("L^-1·M·T^-2") // the dimension of Pa
+func ParseDimension(s string) (Dimension, error) {
+	s = strings.TrimSpace(s)
+	if s == "1" {
+		return Dimensionless, nil
+	}
+
+	var e exponents
+	for _, part := range strings.Split(strings.ReplaceAll(s, "*", "·"), "·") {
+		symbol, exp, hasExp := strings.Cut(strings.TrimSpace(part), "^")
+		i, ok := dimensionIndex(symbol)
+		if !ok {
+			return Dimension{}, fmt.Errorf("unknown base dimension %q in %q", symbol, s)
+		}
+
+		num, den := 1, 1
+		if hasExp {
+			var err error
+			if num, den, err = parseExponent(exp); err != nil {
+				return Dimension{}, fmt.Errorf("invalid exponent of %s in %q: %w", symbol, s, err)
+			}
+		}
+
+		factor, ok := baseExponents(i, num, den)
+		if !ok {
+			return Dimension{}, fmt.Errorf("exponent of %s out of range in %q", symbol, s)
+		}
+		e = e.mul(factor)
+	}
+
+	dim, ok := e.pack()
+	if !ok {
+		return Dimension{}, fmt.Errorf("exponent out of range in %q", s)
+	}
+	if dim == Dimensionless {
+		return Dimension{}, fmt.Errorf("invalid dimension %q", s)
+	}
+	return dim, nil
+}
+
+// baseExponents returns the exponents of base dimension i raised to num/den,
+// reporting false if the exponent cannot be stored
+func baseExponents(i, num, den int) (exponents, bool) {
+	var e exponents
+	if num < -maxExponent || num > maxExponent || den > maxExponent {
+		return e, false
+	}
+	e[i] = num
+	if den != 1 {
+		e[denominatorIndex] = den
+	}
+	return e.normalized(), true
+}
+
+// parseExponent reads an exponent written as "-2" or "(-1/2)"
+func parseExponent(s string) (num, den int, err error) {
+	if inner, ok := strings.CutPrefix(s, "("); ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+		n, d, slash := strings.Cut(inner, "/")
+		if !ok || !slash {
+			return 0, 0, fmt.Errorf("malformed fraction %q", s)
+		}
+		if num, err = strconv.Atoi(n); err != nil {
+			return 0, 0, err
+		}
+		if den, err = strconv.Atoi(d); err != nil {
+			return 0, 0, err
+		}
+		if den <= 0 {
+			return 0, 0, fmt.Errorf("malformed fraction %q", s)
+		}
+		return num, den, nil
+	}
+	num, err = strconv.Atoi(s)
+	return num, 1, err
+}
+
+// GoString returns the dimension as Go syntax without trailing zero exponents,
+// e.g. si.NewDimension(1, 0, -2), which is used by %#v. Rational dimensions are
+// written with MustParseDimension.
+func (d Dimension) GoString() string {
+	if !d.IsInteger() {
+		return "si.MustParseDimension(" + strconv.Quote(d.String()) + ")"
+	}
+
+	e := d.expand()
+	last := numBaseDimensions - 1
+	for last >= 0 && e[last] == 0 {
+		last--
+	}
+
+	parts := make([]string, last+1)
+	for i := range parts {
+		parts[i] = strconv.Itoa(e[i])
+	}
+	return "si.NewDimension(" + strings.Join(parts, ", ") + ")"
+}
+
+// vector writes every SI base exponent, including zeros, e.g. "[L:1 M:0 T:-2 I:0 Θ:0 N:0 J:0]".
+// Information, angle and custom base dimensions are only written when present, as in "[... J:0 D:1]".
+func (d Dimension) vector() string {
+	var parts []string
+	for i := 0; i < numBaseDimensions; i++ {
+		num, den := d.Exponent(i)
+		if i >= numSIDimensions && num == 0 {
+			continue
+		}
+
+		part := dimensionSymbol(i) + ":" + strconv.Itoa(num)
+		if den != 1 {
+			part += "/" + strconv.Itoa(den)
+		}
+		parts = append(parts, part)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// normalized reduces the exponents to lowest terms with a positive denominator,
+// storing a denominator of 1 as zero.
+func (e exponents) normalized() exponents {
+	den := e[denominatorIndex]
+	if den == 0 {
+		return e
+	}
+
+	if den < 0 {
+		for i := range e {
+			e[i] = -e[i]
+		}
+		den = -den
+	}
+
+	g := den
+	for i := 0; i < numBaseDimensions; i++ {
+		g = gcd(g, abs(e[i]))
+	}
+	for i := range e {
+		e[i] /= g
+	}
+
+	if e[denominatorIndex] == 1 {
+		e[denominatorIndex] = 0
+	}
+	return e
+}
+
+// mulDimensions returns the dimension of a product, i.e. the sum of exponents.
+// It panics if an exponent exceeds maxExponent.
+func mulDimensions(a, b Dimension) Dimension {
+	d, ok := tryMulDimensions(a, b)
+	if !ok {
+		panic(errExponentRange)
+	}
+	return d
+}
+
+// tryMulDimensions is mulDimensions reporting false instead of panicking.
+// The parser uses it to return an error.
+func tryMulDimensions(a, b Dimension) (Dimension, bool) {
+	// Fast path: integer exponents of the inline base dimensions add lane by
+	// lane. The low seven bits of every lane are added without carrying into
+	// the next lane, then the sign bits are added separately.
+	if a.ext == nil && b.ext == nil {
+		sum := ((a.exps &^ laneSigns) + (b.exps &^ laneSigns)) ^ ((a.exps ^ b.exps) & laneSigns)
+		// A lane overflows when both operands have one sign and the sum the
+		// other, and the product then needs an extension
+		if (a.exps^sum)&(b.exps^sum)&laneSigns == 0 {
+			return Dimension{exps: sum}, true
+		}
+	}
+	return mulExpanded(a, b)
+}
+
+// mulExpanded is the slow path of tryMulDimensions, kept out of line so that
+// the fast path stays small
+func mulExpanded(a, b Dimension) (Dimension, bool) {
+	return a.expand().mul(b.expand()).pack()
+}
+
+// divDimensions returns the dimension of a quotient, i.e. the difference of exponents.
+// It panics if an exponent exceeds maxExponent.
+func divDimensions(a, b Dimension) Dimension {
+	d, ok := tryDivDimensions(a, b)
+	if !ok {
+		panic(errExponentRange)
+	}
+	return d
+}
+
+// tryDivDimensions is divDimensions reporting false instead of panicking
+func tryDivDimensions(a, b Dimension) (Dimension, bool) {
+	// Fast path: integer exponents of the inline base dimensions subtract lane
+	// by lane. Setting the sign bit of every lane of a keeps borrows from
+	// crossing lanes, and the sign bits are fixed up afterwards.
+	if a.ext == nil && b.ext == nil {
+		diff := ((a.exps | laneSigns) - (b.exps &^ laneSigns)) ^ ((a.exps ^ ^b.exps) & laneSigns)
+		// A lane overflows when the operands differ in sign and the difference
+		// has the sign of the subtrahend
+		if (a.exps^b.exps)&(a.exps^diff)&laneSigns == 0 {
+			return Dimension{exps: diff}, true
+		}
+	}
+	inverse, ok := powExpanded(b, -1, 1)
+	if !ok {
+		return Dimension{}, false
+	}
+	return mulExpanded(a, inverse)
+}
+
+// powDimension multiplies every exponent by num/den.
+// It panics if an exponent exceeds maxExponent.
+func powDimension(d Dimension, num, den int) Dimension {
+	p, ok := tryPowDimension(d, num, den)
+	if !ok {
+		panic(errExponentRange)
+	}
+	return p
+}
+
+// tryPowDimension is powDimension reporting false instead of panicking
+func tryPowDimension(d Dimension, num, den int) (Dimension, bool) {
+	// Fast path: integer exponents of the inline base dimensions raised to a
+	// small integer power, assembled in a register
+	if d.ext == nil && den == 1 && num == int(int8(num)) {
+		var exps uint64
+		fits := true
+		for i := 0; i < inlineDimensions; i++ {
+			product := d.numerator(i) * num
+			fits = fits && product == int(int8(product))
+			exps |= uint64(uint8(product)) << (8 * i)
+		}
+		if fits {
+			return Dimension{exps: exps}, true
+		}
+	}
+	return powExpanded(d, num, den)
+}
+
+// powExpanded is the slow path of tryPowDimension, kept out of line so that
+// the fast path stays small
+func powExpanded(d Dimension, num, den int) (Dimension, bool) {
+	if num < -maxExponent || num > maxExponent || den < 1 || den > maxExponent {
+		return Dimension{}, false
+	}
+	e := d.expand()
+	for i := 0; i < numBaseDimensions; i++ {
+		e[i] *= num
+	}
+	e[denominatorIndex] = d.denominator() * den
+	return e.pack()
+}
+
+// gcd returns the greatest common divisor of two non-negative integers
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,229 @@
+package si_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/gurre/si"
+)
+
+// TestDimensionString verifies the base dimension notation
+func TestDimensionString(t *testing.T) {
+	if got := si.Newton.Dimension.String(); got != "L·M·T^-2" {
+		t.Errorf("String = %q, want %q", got, "L·M·T^-2")
+	}
+}
+
+// TestDimensionStringFractional verifies fractional exponents are parenthesized
+func TestDimensionStringFractional(t *testing.T) {
+	if got := si.Hertz.Sqrt().Dimension.String(); got != "T^(-1/2)" {
+		t.Errorf("String = %q, want %q", got, "T^(-1/2)")
+	}
+}
+
+// TestDimensionStringDimensionless verifies the dimensionless notation
+func TestDimensionStringDimensionless(t *testing.T) {
+	if got := si.Dimensionless.String(); got != "1" {
+		t.Errorf("String = %q, want %q", got, "1")
+	}
+}
+
+// TestDimensionGoString verifies the Go literal omits trailing zero slots
+func TestDimensionGoString(t *testing.T) {
+	if got := si.Newton.Dimension.GoString(); got != "si.NewDimension(1, 1, -2)" {
+		t.Errorf("GoString = %q, want %q", got, "si.NewDimension(1, 1, -2)")
+	}
+}
+
+// TestDimensionGoStringRational verifies that dimensions NewDimension cannot
+// express are written with MustParseDimension
+func TestDimensionGoStringRational(t *testing.T) {
+	d := si.Hertz.Sqrt().Dimension
+	want := `si.MustParseDimension("T^(-1/2)")`
+	if got := d.GoString(); got != want {
+		t.Errorf("GoString = %q, want %q", got, want)
+	}
+	if si.MustParseDimension("T^(-1/2)") != d {
+		t.Errorf("MustParseDimension(%q) != %v", "T^(-1/2)", d)
+	}
+}
+
+// TestNewDimension verifies that NewDimension takes exponents in slot order
+func TestNewDimension(t *testing.T) {
+	if got := si.NewDimension(1, 1, -2); got != si.Newton.Dimension {
+		t.Errorf("NewDimension(1, 1, -2) = %v, want %v", got, si.Newton.Dimension)
+	}
+	if got := si.NewDimension(0, 0, 0, 0, 0, 0, 0, 0, 1); got != si.Angle {
+		t.Errorf("NewDimension of angle = %v, want %v", got, si.Angle)
+	}
+	if si.NewDimension() != si.Dimensionless || si.Dimensionless != (si.Dimension{}) {
+		t.Error("NewDimension() is not the zero dimensionless value")
+	}
+}
+
+// TestDimensionArithmetic verifies that products and quotients add and
+// subtract every exponent, including negative ones and those outside the SI
+func TestDimensionArithmetic(t *testing.T) {
+	for a := -60; a <= 60; a += 7 {
+		for b := -60; b <= 60; b += 11 {
+			exps := func(x int) []int { return []int{x, -x, x / 2, 1, -1, 0, x / 3, -x / 4, x / 5} }
+			da, db := si.NewDimension(exps(a)...), si.NewDimension(exps(b)...)
+			product := si.Unit{Value: 1, Dimension: da}.Mul(si.Unit{Value: 1, Dimension: db}).Dimension
+			quotient := si.Unit{Value: 1, Dimension: da}.Div(si.Unit{Value: 1, Dimension: db}).Dimension
+			for i := range exps(a) {
+				if num, _ := product.Exponent(i); num != exps(a)[i]+exps(b)[i] {
+					t.Fatalf("exponent %d of %v * %v = %d", i, da, db, num)
+				}
+				if num, _ := quotient.Exponent(i); num != exps(a)[i]-exps(b)[i] {
+					t.Fatalf("exponent %d of %v / %v = %d", i, da, db, num)
+				}
+			}
+		}
+	}
+}
+
+// TestDimensionInterned verifies that dimensions built in different ways
+// compare equal when they have rational exponents or angles
+func TestDimensionInterned(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	omega, err := ctx.Parse("3 rad/s")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	pairs := [][2]si.Dimension{
+		{si.Hertz.Sqrt().Dimension, si.MustParse("1 Hz^(1/2)").Dimension},
+		{si.Second.PowRat(-1, 2).Mul(si.Meter).Dimension, si.MustParseDimension("L·T^(-1/2)")},
+		{omega.Dimension, si.MustParseDimension("T^-1·A")},
+		{omega.DropAngle().Dimension, si.Hertz.Dimension},
+	}
+	for _, p := range pairs {
+		if p[0] != p[1] {
+			t.Errorf("%v != %v", p[0], p[1])
+		}
+	}
+}
+
+// TestDimensionLargeExponents verifies that exponents beyond a byte are kept
+// exactly rather than panicking
+func TestDimensionLargeExponents(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1 m^200", "L^200"},
+		{"1 m^(1/200)", "L^(1/200)"},
+		{"1 m^0.001", "L^(1/1000)"},
+		{"1 m^1.3333333", "L^(13333333/10000000)"},
+		{"1 m^100*m^100", "L^200"},
+		{"1 m^127*m", "L^128"},
+		{"1 m^-128", "L^-128"},
+	}
+	for _, tt := range tests {
+		u, err := si.Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got := u.Dimension.String(); got != tt.want {
+			t.Errorf("Parse(%q) dimension = %s, want %s", tt.input, got, tt.want)
+		}
+		// Formatting searches products and quotients of the dimension
+		back, err := si.ParseUnit(u.String())
+		if err != nil || back.Dimension != u.Dimension {
+			t.Errorf("ParseUnit(%q) = %v, %v, want dimension %s", u.String(), back, err, tt.want)
+		}
+		if inv := u.Inv().Inv(); inv.Dimension != u.Dimension {
+			t.Errorf("Inv().Inv() of %s = %s", tt.want, inv.Dimension)
+		}
+	}
+
+	if got := si.Meter.Pow(120).String(); got != "m^120" {
+		t.Errorf("Meter.Pow(120) = %q, want m^120", got)
+	}
+	if got := si.Meter.Pow(100).Mul(si.Meter.Pow(100)).Div(si.Meter.Pow(200)); got.Dimension != si.Dimensionless {
+		t.Errorf("m^200/m^200 = %v, want dimensionless", got.Dimension)
+	}
+	if d, err := si.ParseDimension("L^200"); err != nil || d != si.Meter.Pow(200).Dimension {
+		t.Errorf("ParseDimension(L^200) = %v, %v", d, err)
+	}
+}
+
+// TestDimensionExponentRange verifies that exponents beyond the supported range
+// are rejected by the parsers and decoders as errors
+func TestDimensionExponentRange(t *testing.T) {
+	inputs := []string{
+		"1 m^99999999999",
+		"1 m^(1/99999999999)",
+		"1 m^0.00000000001",
+		"1 m^2147483647*m",
+		"1 (m^2147483647)^2",
+		"1 m^-2147483647/m^2",
+	}
+	for _, input := range inputs {
+		var syntax *si.SyntaxError
+		if _, err := si.Parse(input); !errors.As(err, &syntax) {
+			t.Errorf("Parse(%q) error = %v, want *SyntaxError", input, err)
+		} else if syntax.Input != input {
+			t.Errorf("Parse(%q) error input = %q", input, syntax.Input)
+		}
+
+		var u si.Unit
+		if err := json.Unmarshal([]byte(strconv.Quote(input)), &u); err == nil {
+			t.Errorf("json.Unmarshal(%q) succeeded", input)
+		}
+	}
+
+	var tagged struct {
+		Pressure si.Unit `json:"p_kpa" si:"kPa"`
+	}
+	if err := si.UnmarshalJSON([]byte(`{"p_kpa":"1 m^99999999999"}`), &tagged); err == nil {
+		t.Error("UnmarshalJSON() with an exponent out of range succeeded")
+	}
+	if _, err := si.ParseDimension("L^99999999999"); err == nil {
+		t.Error("ParseDimension(L^99999999999) succeeded, want error")
+	}
+}
+
+// TestUnitSize guards the size of Unit, which is copied by every operation
+func TestUnitSize(t *testing.T) {
+	if size := unsafe.Sizeof(si.Unit{}); size > 40 {
+		t.Errorf("Unit takes %d bytes, want at most 40", size)
+	}
+}
+
+// TestParseDimension verifies that ParseDimension reads what String writes
+func TestParseDimension(t *testing.T) {
+	dims := []si.Dimension{
+		si.Newton.Dimension,
+		si.Pascal.Dimension,
+		si.Volt.Dimension,
+		si.Hertz.Sqrt().Dimension,
+		si.Dimensionless,
+		si.Information,
+	}
+	for _, want := range dims {
+		got, err := si.ParseDimension(want.String())
+		if err != nil {
+			t.Fatalf("ParseDimension(%q) error: %v", want.String(), err)
+		}
+		if got != want {
+			t.Errorf("ParseDimension(%q) = %#v, want %#v", want.String(), got, want)
+		}
+	}
+
+	if got, err := si.ParseDimension("M*L^-1*T^-2"); err != nil || got != si.Pascal.Dimension {
+		t.Errorf("ParseDimension with * = %#v, %v, want %#v", got, err, si.Pascal.Dimension)
+	}
+}
+
+// TestParseDimensionInvalid verifies malformed dimensions are rejected
+func TestParseDimensionInvalid(t *testing.T) {
+	for _, input := range []string{"", "Q", "L^x", "T^(1/0)", "T^(1/2", "L·"} {
+		if _, err := si.ParseDimension(input); err == nil {
+			t.Errorf("ParseDimension(%q) succeeded, want error", input)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package si
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// DisplayProfile lists the units a reader expects quantities in, such as bar for
+// pressure and km/h for speed on a metric dashboard, or psi and mph in the US.
+// Quantities whose dimension has no preferred unit are formatted with the default
+// PrefixPolicy as usual.
+//
+// The default profile is used by String, MarshalJSON and the %v verb; install
+// another with SetDefaultDisplayProfile or pass one to FormatWithProfile.
+// A profile should not be modified once it is in use.
+//
+// Example:
+//
+//	dashboard := si.NewDisplayProfile("dashboard", nil)
+//	dashboard.Prefer("bar")
+//	dashboard.Prefer("L/min")
+//	si.FormatWithProfile(si.Pascals(250000), dashboard) // 2.5 bar
+type DisplayProfile struct {
+	name     string
+	registry *Registry
+	units    []displayUnit
+}
+
+// displayUnit is a preferred unit with the expression it was given as
+type displayUnit struct {
+	symbol string
+	unit   Unit
+}
+
+// NewDisplayProfile returns an empty profile that resolves the symbols passed to
+// Prefer with reg, or with the default registry when reg is nil.
+func NewDisplayProfile(name string, reg *Registry) *DisplayProfile {
+	return &DisplayProfile{name: name, registry: reg}
+}
+
+// Name returns the name the profile was created with
+func (p *DisplayProfile) Name() string {
+	return p.name
+}
+
+// Prefer makes symbol, a unit expression such as "bar", "kWh" or "km/h", the unit
+// for quantities of its dimension. The prefix is kept as written.
+//
+// A preference only applies to quantities of a compatible kind, so "kWh" is used
+// for energies but not for torques, and several preferences may share a dimension:
+// the first compatible one wins.
+func (p *DisplayProfile) Prefer(symbol string) error {
+	reg := p.registry
+	if reg == nil {
+		reg = DefaultRegistry()
+	}
+	u, err := reg.ParseUnit(symbol)
+	if err != nil {
+		return fmt.Errorf("display profile %s: %w", p.name, err)
+	}
+	p.units = append(p.units, displayUnit{symbol: symbol, unit: u})
+	return nil
+}
+
+// mustPrefer is Prefer for the built-in profiles, whose symbols are always defined
+func (p *DisplayProfile) mustPrefer(symbols ...string) *DisplayProfile {
+	for _, symbol := range symbols {
+		if err := p.Prefer(symbol); err != nil {
+			panic(err)
+		}
+	}
+	return p
+}
+
+// parts returns the value of u in the preferred unit for its dimension and the
+// symbol of that unit. It reports false when the profile has no preference.
+// Affine units such as °C only apply to absolute temperatures, so that a
+// difference of 9 K is not shown as -264.15 °C.
+func (p *DisplayProfile) parts(u Unit) (float64, string, bool) {
+	if p == nil || isPlainNumber(u) {
+		return 0, "", false
+	}
+	for _, d := range p.units {
+		if d.unit.Dimension != u.Dimension {
+			continue
+		}
+		if d.unit.IsAbsolute() && !u.IsAbsolute() {
+			continue
+		}
+		if _, err := mergeKinds("display", u, d.unit); err != nil {
+			continue
+		}
+		converted, err := u.ConvertTo(d.unit)
+		if err != nil {
+			continue
+		}
+		return converted.Value, d.symbol, true
+	}
+	return 0, "", false
+}
+
+// SIProfile returns the profile that writes every quantity in SI units with the
+// prefix chosen by the default PrefixPolicy, e.g. "250 kPa" or "27.8 m/s".
+func SIProfile() *DisplayProfile {
+	return NewDisplayProfile("SI", nil)
+}
+
+// EngineeringMetricProfile returns a profile with the metric units common on
+// engineering dashboards: bar, kWh, kW, km/h, L/min, L, and °C for temperatures
+// with K for temperature differences.
+func EngineeringMetricProfile() *DisplayProfile {
+	return NewDisplayProfile("engineering-metric", NewStandardContext()).
+		mustPrefer("bar", "kWh", "kW", "km/h", "L/min", "L", "°C", "K")
+}
+
+// USCustomaryProfile returns a profile with the US customary units: ft, lb, °F
+// with Δ°F for temperature differences, psi, mph, gal, gpm, lbf, BTU, lbf*ft for
+// torque, and hp.
+// Its symbols come from ImperialUnits; load that pack into the registry that
+// decodes the output, e.g. DefaultRegistry().Load(ImperialUnits).
+func USCustomaryProfile() *DisplayProfile {
+	reg := NewStandardContext()
+	if err := reg.Load(ImperialUnits); err != nil {
+		panic(err)
+	}
+	return NewDisplayProfile("US customary", reg).
+		mustPrefer("ft", "lb", "°F", "Δ°F", "psi", "mph", "gal", "gpm", "lbf", "BTU", "lbf*ft", "hp")
+}
+
+// defaultDisplayProfile is the profile used by String and MarshalJSON.
+var defaultDisplayProfile atomic.Pointer[DisplayProfile]
+
+func init() {
+	defaultDisplayProfile.Store(SIProfile())
+}
+
+// DefaultDisplayProfile returns the profile used by String, MarshalJSON and the %v verb
+func DefaultDisplayProfile() *DisplayProfile {
+	return defaultDisplayProfile.Load()
+}
+
+// SetDefaultDisplayProfile replaces the profile used by String, MarshalJSON and
+// the %v verb. Passing nil restores SIProfile. It is safe to call while other
+// goroutines format.
+//
+// Example:
+//
+//	si.SetDefaultDisplayProfile(si.EngineeringMetricProfile())
+//	fmt.Println(si.Pascals(250000)) // 2.5 bar
+func SetDefaultDisplayProfile(p *DisplayProfile) {
+	if p == nil {
+		p = SIProfile()
+	}
+	defaultDisplayProfile.Store(p)
+}
+
+// FormatWithProfile formats u in the unit the profile prefers for its dimension,
+// with up to 12 significant digits to hide the rounding error of the conversion.
+// Quantities the profile has no unit for are formatted as FormatUnitWithPrefix does.
+//
+// Example:
+//
+//	si.FormatWithProfile(si.MustParse("100 km/h"), si.USCustomaryProfile()) // 62.1371192237 mph
+func FormatWithProfile(u Unit, p *DisplayProfile) string {
+	if value, symbol, ok := p.parts(u); ok {
+		return strconv.FormatFloat(value, 'g', 12, 64) + " " + symbol
+	}
+	return FormatUnitWithPrefix(u)
+}
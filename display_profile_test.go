@@ -0,0 +1,143 @@
+package si_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestEngineeringMetricProfile verifies the dashboard units of the metric profile
+func TestEngineeringMetricProfile(t *testing.T) {
+	profile := si.EngineeringMetricProfile()
+	tests := map[string]string{
+		"250 kPa":     "2.5 bar",
+		"7.2 MJ":      "2 kWh",
+		"100 km/h":    "100 km/h",
+		"0.7 L/s":     "42 L/min",
+		"1500 W":      "1.5 kW",
+		"2 m^3":       "2000 L",
+		"21 °C":       "21 °C",
+		"10 m":        "10 m",
+		"5000 N*m":    "5 kN*m",
+		"2 kJ/m^3":    "2 kJ/m^3",
+		"3 kg":        "3 kg",
+		"293.15 K":    "293.15 K",
+		"0.5 MPa":     "5 bar",
+		"3600 kJ":     "1 kWh",
+		"27.7778 m/s": "100.00008 km/h",
+	}
+	for input, want := range tests {
+		if got := si.FormatWithProfile(si.MustParse(input), profile); got != want {
+			t.Errorf("FormatWithProfile(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// TestUSCustomaryProfile verifies the US customary profile
+func TestUSCustomaryProfile(t *testing.T) {
+	profile := si.USCustomaryProfile()
+	tests := map[string]string{
+		"100 km/h":  "62.1371192237 mph",
+		"25 °C":     "77 °F",
+		"0.3048 m":  "1 ft",
+		"1 kg":      "2.20462262185 lb",
+		"20 J":      "0.0189563424063 BTU",
+		"20 N*m":    "14.7512429855 lbf*ft",
+		"101.3 kPa": "14.6923228321 psi",
+	}
+	for input, want := range tests {
+		if got := si.FormatWithProfile(si.MustParse(input), profile); got != want {
+			t.Errorf("FormatWithProfile(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// TestProfilesTemperatureDifference verifies that a temperature difference is
+// not shown on an affine scale by any of the built-in profiles
+func TestProfilesTemperatureDifference(t *testing.T) {
+	diff, err := si.Kelvins(9).Sub(si.Kelvins(0))
+	if err != nil {
+		t.Fatalf("Sub error: %v", err)
+	}
+	tests := []struct {
+		profile *si.DisplayProfile
+		want    string
+	}{
+		{si.SIProfile(), "9 K"},
+		{si.EngineeringMetricProfile(), "9 K"},
+		{si.USCustomaryProfile(), "16.2 Δ°F"},
+	}
+	for _, tt := range tests {
+		if got := si.FormatWithProfile(diff, tt.profile); got != tt.want {
+			t.Errorf("%s: FormatWithProfile(9 K) = %q, want %q", tt.profile.Name(), got, tt.want)
+		}
+	}
+
+	si.SetDefaultDisplayProfile(si.EngineeringMetricProfile())
+	defer si.SetDefaultDisplayProfile(nil)
+	data, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var decoded si.Unit
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Equals(diff) {
+		t.Errorf("Round trip of %s = %v, %v, want 9 K", data, decoded.Value, err)
+	}
+}
+
+// TestSIProfileMatchesPrefixFormatting verifies the SI profile changes nothing
+func TestSIProfileMatchesPrefixFormatting(t *testing.T) {
+	for _, u := range []si.Unit{si.Pascals(250000), si.Joules(7.2e6), si.Celsius(21), si.Scalar(3)} {
+		if got, want := si.FormatWithProfile(u, si.SIProfile()), si.FormatUnitWithPrefix(u); got != want {
+			t.Errorf("FormatWithProfile = %q, want %q", got, want)
+		}
+	}
+}
+
+// TestUserDefinedProfile verifies Prefer resolves symbols and rejects unknown ones
+func TestUserDefinedProfile(t *testing.T) {
+	profile := si.NewDisplayProfile("plant", nil)
+	if err := profile.Prefer("mbar"); err != nil {
+		t.Fatalf("Prefer error: %v", err)
+	}
+	if err := profile.Prefer("furlong"); err == nil {
+		t.Error("Expected error preferring an unknown unit")
+	}
+	if got := si.FormatWithProfile(si.Pascals(250), profile); got != "2.5 mbar" {
+		t.Errorf("FormatWithProfile = %q, want %q", got, "2.5 mbar")
+	}
+	if profile.Name() != "plant" {
+		t.Errorf("Name = %q, want %q", profile.Name(), "plant")
+	}
+}
+
+// TestDefaultDisplayProfile verifies String, MarshalJSON and %v follow the default profile
+func TestDefaultDisplayProfile(t *testing.T) {
+	si.SetDefaultDisplayProfile(si.EngineeringMetricProfile())
+	defer si.SetDefaultDisplayProfile(nil)
+
+	p := si.Pascals(250000)
+	if got := p.String(); got != "2.5 bar" {
+		t.Errorf("String = %q, want %q", got, "2.5 bar")
+	}
+	if got := fmt.Sprintf("%.1f", p); got != "2.5 bar" {
+		t.Errorf("%%.1f = %q, want %q", got, "2.5 bar")
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(data) != `"2.5 bar"` {
+		t.Errorf("MarshalJSON = %s, want %q", data, "2.5 bar")
+	}
+
+	var decoded si.Unit
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !decoded.Equals(p) {
+		t.Errorf("Round trip = %v, want %v", decoded.Value, p.Value)
+	}
+}
@@ -0,0 +1,205 @@
+package si
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNoUnits is returned by the aggregate functions Sum and Mean when called without units
+var ErrNoUnits = errors.New("no units given")
+
+// DimensionMismatchError is returned when an operation needs operands of the same dimension.
+// Use errors.As to inspect the dimensions involved.
+//
+// Example:
+//
+//	_, err := Meters(1).Sub(Seconds(1))
+//	var mismatch *DimensionMismatchError
+//	if errors.As(err, &mismatch) {
+//	    fmt.Println(mismatch.Left, mismatch.Right) // L T
+//	}
+type DimensionMismatchError struct {
+	// Op is the operation that failed, such as "add" or "compare"
+	Op string
+	// Left is the dimension of the receiver or first operand
+	Left Dimension
+	// Right is the dimension of the operand that did not match
+	Right Dimension
+}
+
+// Error implements the error interface
+func (e *DimensionMismatchError) Error() string {
+	return fmt.Sprintf("cannot %s units with different dimensions: %s and %s", e.Op, e.Left, e.Right)
+}
+
+// UnknownUnitError is returned when a unit symbol is not defined in the registry.
+// Use errors.As to tell unknown units apart from malformed input.
+//
+// Example:
+//
+//	_, err := Parse("101 kPA")
+//	var unknown *UnknownUnitError
+//	if errors.As(err, &unknown) {
+//	    fmt.Println(err)            // unrecognized unit: kPA at position 4 (did you mean kPa?)
+//	    fmt.Println(unknown.Caret()) // 101 kPA
+//	                                 //     ^^^
+//	}
+type UnknownUnitError struct {
+	// Symbol is the unit symbol that could not be resolved
+	Symbol string
+	// Pos is the byte offset of the symbol in the parsed input, or -1 when the
+	// symbol was resolved on its own
+	Pos int
+	// Input is the parsed input, when the symbol came from one
+	Input string
+	// Suggestions lists defined symbols similar to Symbol, best match first
+	Suggestions []string
+}
+
+// Error implements the error interface
+func (e *UnknownUnitError) Error() string {
+	msg := fmt.Sprintf("unrecognized unit: %s", e.Symbol)
+	if e.Pos >= 0 {
+		msg += fmt.Sprintf(" at position %d", e.Pos)
+	}
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(e.Suggestions, ", "))
+	}
+	return msg
+}
+
+// RuneOffset returns the position of the symbol counted in runes rather than bytes,
+// which is the column an editor shows. It is -1 when the position is not known.
+func (e *UnknownUnitError) RuneOffset() int {
+	if e.Input == "" {
+		return e.Pos
+	}
+	return runeColumn(e.Input, e.Pos)
+}
+
+// Caret renders the input with the unknown symbol underlined on the following line.
+// It returns an empty string when the input is not known.
+func (e *UnknownUnitError) Caret() string {
+	if e.Pos < 0 || e.Input == "" {
+		return ""
+	}
+	return caretLine(e.Input, e.Pos, utf8.RuneCountInString(e.Symbol))
+}
+
+// SyntaxError is returned when the input is not a well-formed unit expression
+type SyntaxError struct {
+	// Input is the text being parsed
+	Input string
+	// Offset is the byte offset in Input where the problem was found
+	Offset int
+	// Msg describes the problem
+	Msg string
+}
+
+// Error implements the error interface
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Offset)
+}
+
+// RuneOffset returns Offset counted in runes rather than bytes, which is the
+// column an editor shows for input such as "5 µm$".
+func (e *SyntaxError) RuneOffset() int {
+	return runeColumn(e.Input, e.Offset)
+}
+
+// Caret renders the input with a caret under the problem on the following line.
+//
+// Example:
+//
+//	3 m/(s
+//	      ^
+func (e *SyntaxError) Caret() string {
+	return caretLine(e.Input, e.Offset, 1)
+}
+
+// caretLine writes input followed by a line that marks width runes starting at the
+// byte offset. Each rune is assumed to occupy one column, which holds for the
+// symbols used in units.
+func caretLine(input string, offset, width int) string {
+	return input + "\n" + strings.Repeat(" ", runeColumn(input, offset)) + strings.Repeat("^", width)
+}
+
+// runeColumn converts a byte offset in input to a rune offset.
+// Offsets outside the input are clamped so that hand-built errors cannot panic.
+func runeColumn(input string, offset int) int {
+	if offset < 0 {
+		return offset
+	}
+	if offset > len(input) {
+		offset = len(input)
+	}
+	return utf8.RuneCountInString(input[:offset])
+}
+
+// withInput records the parsed input on an *UnknownUnitError or a
+// *SyntaxError of evaluation in err, so that its caret line can be rendered
+func withInput(err error, input string) error {
+	var unknown *UnknownUnitError
+	if errors.As(err, &unknown) && unknown.Pos >= 0 {
+		unknown.Input = input
+	}
+	var syntax *SyntaxError
+	if errors.As(err, &syntax) && syntax.Input == "" {
+		syntax.Input = input
+	}
+	return err
+}
+
+// KindMismatchError is returned when an operation mixes two quantity kinds that
+// share a dimension, such as adding a torque to an energy.
+//
+// Example:
+//
+//	_, err := MustParse("5 N*m").Add(MustParse("5 J"))
+//	var mismatch *KindMismatchError
+//	if errors.As(err, &mismatch) {
+//	    fmt.Println(mismatch.Left, mismatch.Right) // torque energy
+//	}
+type KindMismatchError struct {
+	// Op is the operation that failed, such as "add" or "compare"
+	Op string
+	// Left is the kind of the receiver or first operand
+	Left QuantityKind
+	// Right is the kind of the operand that did not match
+	Right QuantityKind
+}
+
+// Error implements the error interface
+func (e *KindMismatchError) Error() string {
+	return fmt.Sprintf("cannot %s %s and %s", e.Op, e.Left, e.Right)
+}
+
+// AbsoluteTemperatureError is returned when an operation is not defined for
+// absolute temperatures, such as adding 20 °C to 30 °C, or for an absolute
+// temperature and a difference, such as averaging 20 °C and 5 K.
+//
+// Example:
+//
+//	_, err := MustParse("20 °C").Add(MustParse("30 °C"))
+//	var absolute *AbsoluteTemperatureError
+//	if errors.As(err, &absolute) {
+//	    fmt.Println(absolute.Left, absolute.Right) // 20 °C 30 °C
+//	}
+type AbsoluteTemperatureError struct {
+	// Op is the operation that failed, such as "add" or "average"
+	Op string
+	// Left is the receiver or first operand
+	Left Unit
+	// Right is the operand that could not be combined with it
+	Right Unit
+}
+
+// Error implements the error interface
+func (e *AbsoluteTemperatureError) Error() string {
+	if e.Left.IsAbsolute() && e.Right.IsAbsolute() {
+		return fmt.Sprintf("cannot %s two absolute temperatures", e.Op)
+	}
+	return fmt.Sprintf("cannot %s an absolute temperature and a difference", e.Op)
+}
@@ -0,0 +1,223 @@
+package si_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestAddDimensionMismatchError verifies Add reports the mismatching dimensions
+func TestAddDimensionMismatchError(t *testing.T) {
+	_, err := si.Meters(1).Add(si.Kilograms(1))
+
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *DimensionMismatchError, got %v", err)
+	}
+	if mismatch.Op != "add" || mismatch.Left != si.Length || mismatch.Right != si.Mass {
+		t.Errorf("Unexpected error fields: %+v", mismatch)
+	}
+}
+
+// TestCompareDimensionMismatchError verifies Compare returns a typed error
+func TestCompareDimensionMismatchError(t *testing.T) {
+	var mismatch *si.DimensionMismatchError
+	if _, err := si.Meters(1).Compare(si.Seconds(1)); !errors.As(err, &mismatch) {
+		t.Errorf("Expected *DimensionMismatchError, got %v", err)
+	}
+}
+
+// TestConvertToDimensionMismatchError verifies ConvertTo returns a typed error
+func TestConvertToDimensionMismatchError(t *testing.T) {
+	_, err := si.Pascals(1).ConvertTo(si.Newton)
+
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *DimensionMismatchError, got %v", err)
+	}
+	if mismatch.Op != "convert" || mismatch.Right != si.Newton.Dimension {
+		t.Errorf("Unexpected error fields: %+v", mismatch)
+	}
+}
+
+// TestToCelsiusDimensionMismatchError verifies temperature helpers return a typed error
+func TestToCelsiusDimensionMismatchError(t *testing.T) {
+	var mismatch *si.DimensionMismatchError
+	if _, err := si.ToCelsius(si.Meters(1)); !errors.As(err, &mismatch) {
+		t.Errorf("Expected *DimensionMismatchError, got %v", err)
+	}
+}
+
+// TestParseUnknownUnitError verifies an unknown symbol can be detected through wrapping
+func TestParseUnknownUnitError(t *testing.T) {
+	_, err := si.Parse("3 furlong/s")
+
+	var unknown *si.UnknownUnitError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownUnitError, got %v", err)
+	}
+	if unknown.Symbol != "furlong" {
+		t.Errorf("Symbol = %q, want %q", unknown.Symbol, "furlong")
+	}
+}
+
+// TestResolveUnknownUnitError verifies Resolve returns a typed error without a position
+func TestResolveUnknownUnitError(t *testing.T) {
+	_, err := si.DefaultRegistry().Resolve("furlong")
+
+	var unknown *si.UnknownUnitError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownUnitError, got %v", err)
+	}
+	if unknown.Pos != -1 {
+		t.Errorf("Pos = %d, want -1", unknown.Pos)
+	}
+}
+
+// TestParseSyntaxError verifies a malformed expression reports its offset
+func TestParseSyntaxError(t *testing.T) {
+	_, err := si.Parse("3 m/(s")
+
+	var syntax *si.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Fatalf("Expected *SyntaxError, got %v", err)
+	}
+	if syntax.Input != "3 m/(s" || syntax.Offset != 6 {
+		t.Errorf("Unexpected error fields: %+v", syntax)
+	}
+}
+
+// TestParseInvalidCharacterSyntaxError verifies tokenizer errors are typed
+func TestParseInvalidCharacterSyntaxError(t *testing.T) {
+	_, err := si.Parse("5 m$")
+
+	var syntax *si.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Fatalf("Expected *SyntaxError, got %v", err)
+	}
+	if syntax.Offset != 3 {
+		t.Errorf("Offset = %d, want 3", syntax.Offset)
+	}
+}
+
+// TestParseErrorsAreDistinct verifies unknown units are not reported as syntax errors
+func TestParseErrorsAreDistinct(t *testing.T) {
+	_, err := si.Parse("3 furlong")
+
+	var syntax *si.SyntaxError
+	if errors.As(err, &syntax) {
+		t.Errorf("Unknown unit reported as *SyntaxError: %v", err)
+	}
+}
+
+// TestUnknownUnitSuggestion verifies a case slip suggests the defined symbol
+func TestUnknownUnitSuggestion(t *testing.T) {
+	_, err := si.Parse("101 kPA")
+
+	var unknown *si.UnknownUnitError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownUnitError, got %v", err)
+	}
+	if len(unknown.Suggestions) != 1 || unknown.Suggestions[0] != "kPa" {
+		t.Errorf("Suggestions = %v, want [kPa]", unknown.Suggestions)
+	}
+	if want := "unrecognized unit: kPA at position 4 (did you mean kPa?)"; err.Error() != want {
+		t.Errorf("Error = %q, want %q", err.Error(), want)
+	}
+}
+
+// TestUnknownUnitNoSuggestion verifies distant symbols are not suggested
+func TestUnknownUnitNoSuggestion(t *testing.T) {
+	var unknown *si.UnknownUnitError
+	if _, err := si.Parse("5 furlong"); !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownUnitError, got %v", err)
+	}
+	if len(unknown.Suggestions) != 0 {
+		t.Errorf("Suggestions = %v, want none", unknown.Suggestions)
+	}
+}
+
+// TestUnknownUnitCaret verifies the symbol is underlined in the caret line
+func TestUnknownUnitCaret(t *testing.T) {
+	var unknown *si.UnknownUnitError
+	if _, err := si.Parse("101 kPA"); !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownUnitError, got %v", err)
+	}
+	if want := "101 kPA\n    ^^^"; unknown.Caret() != want {
+		t.Errorf("Caret = %q, want %q", unknown.Caret(), want)
+	}
+}
+
+// TestUnknownUnitRuneOffset verifies positions after multi-byte runes
+func TestUnknownUnitRuneOffset(t *testing.T) {
+	var unknown *si.UnknownUnitError
+	if _, err := si.ParseUnit("N·kPA"); !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownUnitError, got %v", err)
+	}
+	if unknown.Pos != 3 {
+		t.Errorf("Pos = %d, want 3", unknown.Pos)
+	}
+	if unknown.RuneOffset() != 2 {
+		t.Errorf("RuneOffset = %d, want 2", unknown.RuneOffset())
+	}
+}
+
+// TestSyntaxErrorCaret verifies the caret points at the problem
+func TestSyntaxErrorCaret(t *testing.T) {
+	var syntax *si.SyntaxError
+	if _, err := si.Parse("5 µm$"); !errors.As(err, &syntax) {
+		t.Fatalf("Expected *SyntaxError, got %v", err)
+	}
+	if syntax.Offset != 5 || syntax.RuneOffset() != 4 {
+		t.Errorf("Offset = %d, RuneOffset = %d, want 5 and 4", syntax.Offset, syntax.RuneOffset())
+	}
+	if want := "5 µm$\n    ^"; syntax.Caret() != want {
+		t.Errorf("Caret = %q, want %q", syntax.Caret(), want)
+	}
+}
+
+// TestNewTokenizerErr verifies tokenizer errors are kept rather than discarded
+func TestNewTokenizerErr(t *testing.T) {
+	var syntax *si.SyntaxError
+	if err := si.NewTokenizer("m$").Err(); !errors.As(err, &syntax) {
+		t.Errorf("Expected *SyntaxError, got %v", err)
+	}
+}
+
+// TestNewParserTokenizerErr verifies Parse reports a tokenizer error
+func TestNewParserTokenizerErr(t *testing.T) {
+	var syntax *si.SyntaxError
+	if _, err := si.NewParser("m$").Parse(); !errors.As(err, &syntax) {
+		t.Errorf("Expected *SyntaxError, got %v", err)
+	}
+}
+
+// TestAbsoluteTemperatureError verifies operations on absolute temperatures return a typed error
+func TestAbsoluteTemperatureError(t *testing.T) {
+	room, warm := si.MustParse("20 °C"), si.MustParse("30 °C")
+	tests := []struct {
+		op   string
+		err  error
+		want string
+	}{
+		{"add", second(room.Add(warm)), "cannot add two absolute temperatures"},
+		{"subtract", second(si.Kelvins(5).Sub(warm)), "cannot subtract an absolute temperature and a difference"},
+		{"average", second(si.Mean(room, si.Kelvins(5))), "cannot average an absolute temperature and a difference"},
+	}
+	for _, tt := range tests {
+		var absolute *si.AbsoluteTemperatureError
+		if !errors.As(tt.err, &absolute) {
+			t.Errorf("Expected *AbsoluteTemperatureError, got %v", tt.err)
+			continue
+		}
+		if absolute.Op != tt.op || tt.err.Error() != tt.want {
+			t.Errorf("Error() = %q (op %q), want %q (op %q)", tt.err, absolute.Op, tt.want, tt.op)
+		}
+	}
+}
+
+// second returns the error of a call returning a unit and an error
+func second(_ si.Unit, err error) error {
+	return err
+}
@@ -8,7 +8,7 @@ import (
 
 func main() {
 	// Create units with various representations
-	temp := si.Celsius(25.5)                                     // 298.65 K
+	temp := si.Celsius(25.5)                                     // 25.5 °C
 	distance := si.Kilometers(1.5)                               // 1.5 km
 	flow := si.Meter.Pow(3).Mul(si.Scalar(0.002)).Div(si.Second) // 0.002 m^3/s
 	fmt.Println(temp, distance, flow)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/gurre/si"
 )
@@ -14,14 +15,14 @@ func main() {
 	atmosphericPressure, _ := si.Parse("14.7 psi")
 
 	// Calculate pressure difference
-	pressureDiff, _ := tirePressure.Add(atmosphericPressure.Mul(si.Scalar(-1)))
+	pressureDiff, _ := tirePressure.Sub(atmosphericPressure)
 
 	// Convert to different pressure units
 	pressureKPa, _ := si.ToKiloPascals(pressureDiff)
 
 	// Create a circular area (1 inch radius)
-	radius := si.Meter.Mul(si.Scalar(0.0254))     // 1 inch in meters
-	area := radius.Pow(2).Mul(si.Scalar(3.14159)) // πr²
+	radius := si.Inches(1)
+	area := radius.Pow(2).Mul(si.Scalar(math.Pi)) // πr²
 
 	// Calculate force from pressure (F = P × A)
 	force := pressureDiff.Mul(area)
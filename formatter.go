@@ -2,7 +2,12 @@ package si
 
 import (
 	"fmt"
+	"maps"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Formatter provides a contract for formatting AST nodes into strings
@@ -18,10 +23,17 @@ type FormatOptions struct {
 	DivSymbol string
 	// ExponentFmt defines the exponent format (default "^%d")
 	ExponentFmt string
+	// FractionFmt defines the format of fractional exponents (default "^(%d/%d)")
+	FractionFmt string
+	// RootSymbols renders exponents 1/2 and 1/3 as √ and ∛ (default false)
+	RootSymbols bool
 	// UseParens determines if parentheses should be used (default true)
 	UseParens bool
-	// Simplify controls whether to simplify units (default false)
+	// Simplify writes compound units with the fewest named units, e.g. kg*m^2/(s^3*A)
+	// as V and N*m/s as W (default false); see Simplify
 	Simplify bool
+	// SimplifyOptions configures the search used when Simplify is set
+	SimplifyOptions SimplifyOptions
 	// CollapseSymbols controls whether to use known symbolic names (default true)
 	CollapseSymbols bool
 	// KnownSymbols maps dimensions to their symbolic names
@@ -34,6 +46,7 @@ func DefaultFormatOptions() FormatOptions {
 		MultSymbol:      "*",
 		DivSymbol:       "/",
 		ExponentFmt:     "^%d",
+		FractionFmt:     "^(%d/%d)",
 		UseParens:       true,
 		Simplify:        false,
 		CollapseSymbols: true,
@@ -41,16 +54,30 @@ func DefaultFormatOptions() FormatOptions {
 	}
 }
 
-// defaultKnownSymbols returns a map of common dimensions to their symbolic names
+// standardSymbols maps the dimensions of the named SI derived units to their
+// symbols. Units that share a dimension with another, such as Bq with Hz, Gy
+// with Sv and J/kg, lm with cd or kat with mol/s, are left out so that the
+// formatter never guesses; their symbols come from the unit's QuantityKind.
+var standardSymbols = map[Dimension]string{
+	Newton.Dimension:  "N",
+	Joule.Dimension:   "J",
+	Watt.Dimension:    "W",
+	Pascal.Dimension:  "Pa",
+	Hertz.Dimension:   "Hz",
+	Coulomb.Dimension: "C",
+	Volt.Dimension:    "V",
+	Ohm.Dimension:     "Ω",
+	Siemens.Dimension: "S",
+	Farad.Dimension:   "F",
+	Henry.Dimension:   "H",
+	Weber.Dimension:   "Wb",
+	Tesla.Dimension:   "T",
+}
+
+// defaultKnownSymbols returns a map of common dimensions to their symbolic names.
+// It is a copy, so callers may change their FormatOptions freely.
 func defaultKnownSymbols() map[Dimension]string {
-	return map[Dimension]string{
-		Newton.Dimension: "N",
-		Joule.Dimension:  "J",
-		Watt.Dimension:   "W",
-		Pascal.Dimension: "Pa",
-		Hertz.Dimension:  "Hz",
-		Volt.Dimension:   "V",
-	}
+	return maps.Clone(standardSymbols)
 }
 
 // DefaultFormatter implements the Formatter interface with default settings
@@ -65,8 +92,58 @@ func NewDefaultFormatter() *DefaultFormatter {
 	}
 }
 
-// Format formats a node into a string using the default options
+// Format formats a node into a string using the default options.
+// With Simplify set, a compound unit expression that the default registry
+// resolves to a coherent SI unit is rewritten first, so "V*A" becomes "W".
+// Expressions of a quantity kind, such as "N*m" for torque, are kept as written
+// so that a torque is not turned into "J".
 func (f *DefaultFormatter) Format(node Node) (string, error) {
+	if f.Options.Simplify && !isIdentNode(node) {
+		if u, err := EvalAST(node, DefaultRegistry()); err == nil && u.Value == 1 && !u.IsAbsolute() && u.Kind == KindNone {
+			if factors, ok := Simplify(u.Dimension, &f.Options.SimplifyOptions); ok && hasNamedUnit(factors) {
+				return f.formatNode(factorsToAST(factors))
+			}
+		}
+	}
+	return f.formatNode(node)
+}
+
+// FormatDimension writes a dimension as a unit symbol: the known symbol when
+// CollapseSymbols is set and there is one, the simplest product of named units
+// when Simplify is set, and base units otherwise.
+//
+// Example:
+//
+//	f := si.NewDefaultFormatter()
+//	f.Options.Simplify = true
+//	str, _ := f.FormatDimension(si.Watt.Div(si.Meter.Pow(2).Mul(si.Kelvin)).Dimension) // W/(m^2*K)
+func (f *DefaultFormatter) FormatDimension(d Dimension) (string, error) {
+	if f.Options.CollapseSymbols {
+		if symbol, ok := f.Options.KnownSymbols[d]; ok {
+			return symbol, nil
+		}
+		if !d.IsInteger() {
+			if str, ok := f.formatKnownPower(d); ok {
+				return str, nil
+			}
+		}
+	}
+
+	if f.Options.Simplify {
+		if factors, ok := Simplify(d, &f.Options.SimplifyOptions); ok && hasNamedUnit(factors) {
+			return f.formatNode(factorsToAST(factors))
+		}
+	}
+
+	node, err := dimensionToAST(d)
+	if err != nil {
+		return "", err
+	}
+	return f.formatNode(node)
+}
+
+// formatNode formats a node as written, without simplifying it
+func (f *DefaultFormatter) formatNode(node Node) (string, error) {
 	if node == nil {
 		return "", fmt.Errorf("cannot format nil node")
 	}
@@ -79,12 +156,12 @@ func (f *DefaultFormatter) Format(node Node) (string, error) {
 		return fmt.Sprintf("%g", n.Value), nil
 
 	case *BinaryNode:
-		left, err := f.Format(n.Left)
+		left, err := f.formatNode(n.Left)
 		if err != nil {
 			return "", err
 		}
 
-		right, err := f.Format(n.Right)
+		right, err := f.formatNode(n.Right)
 		if err != nil {
 			return "", err
 		}
@@ -111,13 +188,13 @@ func (f *DefaultFormatter) Format(node Node) (string, error) {
 		return left + op + right, nil
 
 	case *PowerNode:
-		base, err := f.Format(n.Base)
+		base, err := f.formatNode(n.Base)
 		if err != nil {
 			return "", err
 		}
 
 		// Don't show exponent 1 if simplify is enabled
-		if n.Exp == 1 && f.Options.Simplify {
+		if n.Exp == 1 && n.Den <= 1 && f.Options.Simplify {
 			return base, nil
 		}
 
@@ -126,10 +203,17 @@ func (f *DefaultFormatter) Format(node Node) (string, error) {
 			base = "(" + base + ")"
 		}
 
+		if n.Den > 1 {
+			// Roots are written in front of the base, as in V/√Hz
+			if root, ok := rootSymbols[n.Den]; ok && n.Exp == 1 && f.Options.RootSymbols {
+				return root + base, nil
+			}
+			return base + f.formatFraction(n.Exp, n.Den), nil
+		}
 		return base + fmt.Sprintf(f.Options.ExponentFmt, n.Exp), nil
 
 	case *GroupNode:
-		inner, err := f.Format(n.Inner)
+		inner, err := f.formatNode(n.Inner)
 		if err != nil {
 			return "", err
 		}
@@ -144,6 +228,93 @@ func (f *DefaultFormatter) Format(node Node) (string, error) {
 	}
 }
 
+// rootSymbols are the radical signs used for exponents 1/2 and 1/3
+var rootSymbols = map[int]string{2: "√", 3: "∛"}
+
+// formatFraction formats a fractional exponent using FractionFmt
+func (f *DefaultFormatter) formatFraction(num, den int) string {
+	fractionFmt := f.Options.FractionFmt
+	if fractionFmt == "" {
+		// Options built before fractional exponents existed leave this unset
+		fractionFmt = "^(%d/%d)"
+	}
+	return fmt.Sprintf(fractionFmt, num, den)
+}
+
+// formatKnownPower writes a fractional dimension using the known symbols, either as
+// a root of one symbol such as Hz^(1/2) or as a symbol times or divided by such a
+// root, as in V/Hz^(1/2). Symbols are tried in sorted order for stable output.
+func (f *DefaultFormatter) formatKnownPower(d Dimension) (string, bool) {
+	symbols := make([]string, 0, len(f.Options.KnownSymbols))
+	bySymbol := make(map[string]Dimension, len(f.Options.KnownSymbols))
+	for dim, symbol := range f.Options.KnownSymbols {
+		if dim.IsInteger() {
+			symbols = append(symbols, symbol)
+			bySymbol[symbol] = dim
+		}
+	}
+	sort.Strings(symbols)
+
+	// A single root such as Hz^(1/2); negative powers read better in base units
+	for _, symbol := range symbols {
+		if num, den, ok := rationalPowerOf(d, bySymbol[symbol]); ok && num > 0 && den > 1 {
+			str, err := f.formatNode(&PowerNode{Base: &IdentNode{Symbol: symbol}, Exp: num, Den: den})
+			return str, err == nil
+		}
+	}
+
+	// A symbol combined with a root of another, such as V/Hz^(1/2)
+	for _, symbol := range symbols {
+		rest := divDimensions(d, bySymbol[symbol])
+		for _, root := range symbols {
+			num, den, ok := rationalPowerOf(rest, bySymbol[root])
+			if !ok || den == 1 {
+				continue
+			}
+
+			op := Multiply
+			if num < 0 {
+				op = Divide
+			}
+			str, err := f.formatNode(&BinaryNode{
+				Op:    op,
+				Left:  &IdentNode{Symbol: symbol},
+				Right: &PowerNode{Base: &IdentNode{Symbol: root}, Exp: abs(num), Den: den},
+			})
+			return str, err == nil
+		}
+	}
+
+	return "", false
+}
+
+// rationalPowerOf finds num/den such that d equals the integer dimension k raised to num/den
+func rationalPowerOf(d, k Dimension) (num, den int, ok bool) {
+	for i := 0; i < numBaseDimensions; i++ {
+		if k.numerator(i) == 0 {
+			continue
+		}
+
+		num, den = d.numerator(i), d.denominator()*k.numerator(i)
+		if den < 0 {
+			num, den = -num, -den
+		}
+		g := gcd(abs(num), den)
+		num, den = num/g, den/g
+		if num == 0 || powDimension(k, num, den) != d {
+			return 0, 0, false
+		}
+		return num, den, true
+	}
+	return 0, 0, false
+}
+
+// isIdentNode checks if a node is a single unit symbol
+func isIdentNode(node Node) bool {
+	_, ok := node.(*IdentNode)
+	return ok
+}
+
 // isBinaryNode checks if a node is a binary operation
 func isBinaryNode(node Node) bool {
 	_, ok := node.(*BinaryNode)
@@ -155,10 +326,53 @@ func isBinaryDivision(node *BinaryNode) bool {
 	return node.Op == Divide
 }
 
+// formatAffine renders an absolute temperature on the affine scale it was expressed in,
+// e.g. "25 °C". The subtraction of the offset costs precision, so the value is
+// limited to 12 significant digits to avoid output like "25.000000000000004 °C".
+func formatAffine(u Unit) (string, bool) {
+	value, symbol, ok := affineParts(u)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatFloat(value, 'g', 12, 64) + " " + symbol, true
+}
+
+// affineParts returns the value of an absolute temperature on its own scale and
+// the symbol of that scale. It reports false for units that are not absolute.
+func affineParts(u Unit) (float64, string, bool) {
+	if !u.IsAbsolute() {
+		return 0, "", false
+	}
+
+	symbol, scale, ok := DefaultRegistry().affineSymbol(u.Offset)
+	if !ok {
+		return 0, "", false
+	}
+
+	return (u.Value - u.Offset) / scale.step(), symbol, true
+}
+
+// FormatIn formats a unit in the target unit expression with the given number of
+// digits after the decimal point. A negative precision uses up to 12 significant
+// digits, which hides the rounding error of the conversion as formatAffine does.
+// The target is parsed with the default registry; use Registry.FormatIn for others.
+//
+// Example:
+//
+//	str, _ := FormatIn(Pascals(220000), "psi", 1) // "31.9 psi"
+func FormatIn(u Unit, target string, precision int) (string, error) {
+	return DefaultRegistry().FormatIn(u, target, precision)
+}
+
 // FormatUnit formats a Unit into a readable string
 func FormatUnit(u Unit) string {
-	// If dimensionless, just return the value
-	if u.Dimension == Dimensionless {
+	// Absolute temperatures keep the scale they were expressed in
+	if str, ok := formatAffine(u); ok {
+		return str
+	}
+
+	// Plain numbers are just their value, while a tagged angle keeps its unit
+	if isPlainNumber(u) {
 		return fmt.Sprintf("%g", u.Value)
 	}
 
@@ -171,8 +385,8 @@ func FormatUnit(u Unit) string {
 
 	// Format with value if needed
 	if u.Value != 1.0 {
-		// Special formatting for energy density (pressure)
-		if u.Dimension == Pascal.Dimension {
+		// Special formatting for energy density (pressure), unless tagged as energy density
+		if u.Dimension == Pascal.Dimension && u.Kind == KindNone {
 			return fmt.Sprintf("%g Pa", u.Value)
 		}
 		return fmt.Sprintf("%g %s", u.Value, fmtStr)
@@ -181,131 +395,99 @@ func FormatUnit(u Unit) string {
 	return fmtStr
 }
 
-// formatUnitDimension attempts to format a unit using the formatter
+// formatUnitDimension attempts to format a unit using the formatter.
+// Compound units are simplified, so thermal conductivity reads W/(m*K) rather
+// than kg*m/(s^3*K). The symbols of dimensions are cached, as simplifying one
+// searches hundreds of products of named units.
 func formatUnitDimension(u Unit) (string, error) {
-	// Check for known symbolic units first
-	formatter := NewDefaultFormatter()
+	// The kind tells apart units of the same dimension, such as N*m and J
+	if lead, rest, ok := kindSymbol(u); ok {
+		return lead + rest, nil
+	}
+	if symbol, ok := dimensionSymbols.Load(u.Dimension); ok {
+		return symbol.(string), nil
+	}
 
-	if formatter.Options.CollapseSymbols {
-		if symbol, ok := formatter.Options.KnownSymbols[u.Dimension]; ok {
-			return symbol, nil
-		}
+	formatter := NewDefaultFormatter()
+	formatter.Options.Simplify = true
+	symbol, err := formatter.FormatDimension(u.Dimension)
+	if err == nil && dimensionSymbolCount.Add(1) <= maxDimensionSymbols {
+		dimensionSymbols.Store(u.Dimension, symbol)
 	}
+	return symbol, err
+}
+
+// dimensionSymbols caches the symbols formatUnitDimension writes, keyed by Dimension
+var dimensionSymbols sync.Map
+
+// dimensionSymbolCount counts the symbols stored in dimensionSymbols
+var dimensionSymbolCount atomic.Int64
 
-	// Special case handling for complex units
-	if Watt.Div(Meter.Mul(Kelvin)).Dimension == u.Dimension {
-		return "W/(m*K)", nil // Thermal conductivity
-	} else if Joule.Div(Kilogram.Mul(Kelvin)).Dimension == u.Dimension {
-		return "J/(kg*K)", nil // Specific heat capacity
-	} else if u.Dimension == Joule.Div(Meter.Pow(3)).Dimension {
-		return "Pa", nil // Energy density is equivalent to pressure
+// maxDimensionSymbols bounds dimensionSymbols, so that programs computing ever
+// new dimensions do not grow it without limit
+const maxDimensionSymbols = 4096
+
+// numeratorOrder lists the base dimensions in the order their units are written
+// in a numerator: mass first, then length, so that torque reads kg*m^2/s^2
+var numeratorOrder = append([]int{1, 0}, baseIndexRange(2, numBaseDimensions)...)
+
+// denominatorOrder lists the base dimensions in the order their units are
+// written in a denominator, with length and mass last
+var denominatorOrder = append(baseIndexRange(2, numBaseDimensions), 0, 1)
+
+// baseIndexRange returns the slot indexes from lo up to but excluding hi
+func baseIndexRange(lo, hi int) []int {
+	indexes := make([]int, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		indexes = append(indexes, i)
 	}
+	return indexes
+}
 
-	// Generate an AST for this dimension
-	node, err := dimensionToAST(u.Dimension)
-	if err != nil {
-		return "", err
+// dimensionFactor returns symbol raised to the positive power num/den
+func dimensionFactor(symbol string, num, den int) Node {
+	identNode := &IdentNode{Symbol: symbol}
+	if num == 1 && den == 1 {
+		return identNode
 	}
 
-	// Format the AST
-	return formatter.Format(node)
+	node := &PowerNode{Base: identNode, Exp: num}
+	if den != 1 {
+		node.Den = den
+	}
+	return node
 }
 
 // dimensionToAST converts a Dimension to an AST node
 func dimensionToAST(dim Dimension) (Node, error) {
-	symbols := []string{"m", "kg", "s", "A", "K", "mol", "cd"}
 	var numerator []Node
 	var denominator []Node
 
 	// Process dimensions in a specific order to ensure consistent output
 	// First add mass, then length, then other positive dimensions
 	// This ensures kg*m instead of m*kg
-
-	// First pass: add kg if present (mass)
-	if dim[1] > 0 {
-		identNode := &IdentNode{Symbol: symbols[1]}
-		if dim[1] != 1 {
-			numerator = append(numerator, &PowerNode{
-				Base: identNode,
-				Exp:  dim[1],
-			})
-		} else {
-			numerator = append(numerator, identNode)
+	for _, i := range numeratorOrder {
+		if num, den := dim.Exponent(i); num > 0 {
+			numerator = append(numerator, dimensionFactor(baseUnitSymbol(i), num, den))
 		}
 	}
 
-	// Second pass: add m if present (length)
-	if dim[0] > 0 {
-		identNode := &IdentNode{Symbol: symbols[0]}
-		if dim[0] != 1 {
-			numerator = append(numerator, &PowerNode{
-				Base: identNode,
-				Exp:  dim[0],
-			})
-		} else {
-			numerator = append(numerator, identNode)
-		}
-	}
-
-	// Third pass: add other dimensions in order
-	for i, exp := range dim {
-		if i == 0 || i == 1 || exp == 0 {
-			continue // Skip length, mass (already handled) and zero exponents
-		}
-
-		identNode := &IdentNode{Symbol: symbols[i]}
-
-		if exp > 0 {
-			// Add exponent if not 1
-			if exp != 1 {
-				numerator = append(numerator, &PowerNode{
-					Base: identNode,
-					Exp:  exp,
-				})
-			} else {
-				numerator = append(numerator, identNode)
-			}
-		} else {
-			// Add exponent if not -1
-			if exp != -1 {
-				denominator = append(denominator, &PowerNode{
-					Base: identNode,
-					Exp:  -exp,
-				})
-			} else {
-				denominator = append(denominator, identNode)
-			}
-		}
-	}
-
-	// Now handle negative exponents for length and mass
-	if dim[0] < 0 {
-		identNode := &IdentNode{Symbol: symbols[0]}
-		if dim[0] != -1 {
-			denominator = append(denominator, &PowerNode{
-				Base: identNode,
-				Exp:  -dim[0],
-			})
-		} else {
-			denominator = append(denominator, identNode)
+	// Negative exponents go in the denominator, with length and mass last
+	for _, i := range denominatorOrder {
+		if num, den := dim.Exponent(i); num < 0 {
+			denominator = append(denominator, dimensionFactor(baseUnitSymbol(i), -num, den))
 		}
 	}
 
-	if dim[1] < 0 {
-		identNode := &IdentNode{Symbol: symbols[1]}
-		if dim[1] != -1 {
-			denominator = append(denominator, &PowerNode{
-				Base: identNode,
-				Exp:  -dim[1],
-			})
-		} else {
-			denominator = append(denominator, identNode)
-		}
-	}
+	return fractionNode(numerator, denominator), nil
+}
 
+// fractionNode divides the product of the numerator factors by the product of
+// the denominator factors
+func fractionNode(numerator, denominator []Node) Node {
 	// Handle special cases
 	if len(numerator) == 0 && len(denominator) == 0 {
-		return &NumberNode{Value: 1}, nil
+		return &NumberNode{Value: 1}
 	}
 
 	if len(numerator) == 0 {
@@ -330,7 +512,7 @@ func dimensionToAST(dim Dimension) (Node, error) {
 
 	// If there are no denominators, return just the numerator
 	if len(denominator) == 0 {
-		return numNode, nil
+		return numNode
 	}
 
 	// Build the denominator part
@@ -354,32 +536,32 @@ func dimensionToAST(dim Dimension) (Node, error) {
 		Op:    Divide,
 		Left:  numNode,
 		Right: denomNode,
-	}, nil
+	}
 }
 
 // formatDimensionFallback provides a fallback dimension formatter
 func formatDimensionFallback(d Dimension) string {
-	symbols := []string{"m", "kg", "s", "A", "K", "mol", "cd"}
 	var numerator []string
 	var denominator []string
 
-	for i, exp := range d {
-		if exp == 0 {
+	for i := 0; i < numBaseDimensions; i++ {
+		num, den := d.Exponent(i)
+		if num == 0 {
 			continue
 		}
 
-		if exp > 0 {
-			if exp == 1 {
-				numerator = append(numerator, symbols[i])
-			} else {
-				numerator = append(numerator, fmt.Sprintf("%s^%d", symbols[i], exp))
-			}
+		factor := baseUnitSymbol(i)
+		switch {
+		case den != 1:
+			factor += fmt.Sprintf("^(%d/%d)", abs(num), den)
+		case abs(num) != 1:
+			factor += fmt.Sprintf("^%d", abs(num))
+		}
+
+		if num > 0 {
+			numerator = append(numerator, factor)
 		} else {
-			if exp == -1 {
-				denominator = append(denominator, symbols[i])
-			} else {
-				denominator = append(denominator, fmt.Sprintf("%s^%d", symbols[i], -exp))
-			}
+			denominator = append(denominator, factor)
 		}
 	}
 
@@ -433,8 +615,8 @@ func FormatUnitWithOptions(u Unit, opts *FormatOptions) string {
 		opts = &defOpts
 	}
 
-	// If dimensionless, just return the value
-	if u.Dimension == Dimensionless {
+	// Plain numbers are just their value, while a tagged angle keeps its unit
+	if isPlainNumber(u) {
 		return fmt.Sprintf("%g", u.Value)
 	}
 
@@ -445,7 +627,8 @@ func FormatUnitWithOptions(u Unit, opts *FormatOptions) string {
 
 	// Check for known symbolic units first
 	if formatter.Options.CollapseSymbols {
-		if symbol, ok := formatter.Options.KnownSymbols[u.Dimension]; ok {
+		if lead, rest, ok := kindSymbol(u); ok {
+			symbol := lead + strings.NewReplacer("*", opts.MultSymbol, "/", opts.DivSymbol).Replace(rest)
 			if u.Value != 1.0 {
 				return fmt.Sprintf("%g %s", u.Value, symbol)
 			}
@@ -453,19 +636,8 @@ func FormatUnitWithOptions(u Unit, opts *FormatOptions) string {
 		}
 	}
 
-	// Generate an AST for this dimension
-	node, err := dimensionToAST(u.Dimension)
-	if err != nil {
-		// Fallback to simple format
-		unitStr := formatDimensionFallback(u.Dimension)
-		if u.Value != 1.0 {
-			return fmt.Sprintf("%g %s", u.Value, unitStr)
-		}
-		return unitStr
-	}
-
-	// Format the AST
-	unitStr, err := formatter.Format(node)
+	// Known symbols, simplification and the plain AST, as the options ask
+	unitStr, err := formatter.FormatDimension(u.Dimension)
 	if err != nil {
 		unitStr = formatDimensionFallback(u.Dimension)
 	}
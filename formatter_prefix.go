@@ -3,6 +3,7 @@ package si
 import (
 	"fmt"
 	"math"
+	"strings"
 )
 
 // PrefixedFormatter extends the DefaultFormatter with support for SI prefixes
@@ -27,10 +28,9 @@ func (f *PrefixedFormatter) Format(node Node) (string, error) {
 
 	// For simple values, no prefixing is needed
 	if value, ok := extractSimpleValue(node); ok {
-		prefix, scaled := computePrefix(value)
-		if prefix != "" {
-			switch n := node.(type) {
-			case *IdentNode:
+		switch n := node.(type) {
+		case *IdentNode:
+			if prefix, scaled := DefaultPrefixPolicy().Choose(n.Symbol, value); prefix != "" {
 				return fmt.Sprintf("%g %s%s", scaled, prefix, n.Symbol), nil
 			}
 		}
@@ -39,45 +39,114 @@ func (f *PrefixedFormatter) Format(node Node) (string, error) {
 	return str, nil
 }
 
-// FormatUnitWithPrefix formats a unit with appropriate SI prefixes
+// FormatUnitWithPrefix formats a unit with the prefix chosen by the default PrefixPolicy
 func FormatUnitWithPrefix(u Unit) string {
-	// If dimensionless, just return the value
-	if u.Dimension == Dimensionless {
+	return FormatUnitWithPolicy(u, DefaultPrefixPolicy())
+}
+
+// FormatUnitWithPolicy formats a unit with the prefix chosen by policy.
+//
+// Example:
+//
+//	si.FormatUnitWithPolicy(si.Watts(5e12), si.StandardPrefixPolicy())          // 5 TW
+//	si.FormatUnitWithPolicy(si.Watts(5e12), si.PrefixPolicy{Steps: si.NoPrefixes}) // 5e+12 W
+func FormatUnitWithPolicy(u Unit, policy PrefixPolicy) string {
+	// Absolute temperatures keep the scale they were expressed in
+	if str, ok := formatAffine(u); ok {
+		return str
+	}
+
+	// Plain numbers are just their value, while a tagged angle keeps its unit
+	if isPlainNumber(u) {
 		return fmt.Sprintf("%g", u.Value)
 	}
 
-	// Handle special case for scaled base units
-	for i, exp := range u.Dimension {
-		if isBaseSIUnit(u.Dimension, i, exp) {
-			symbols := []string{"m", "kg", "s", "A", "K", "mol", "cd"}
-			prefix, scaled := computePrefix(u.Value)
-			return fmt.Sprintf("%g %s%s", scaled, prefix, symbols[i])
+	if scaled, symbol, ok := prefixedParts(u, policy); ok {
+		return fmt.Sprintf("%g %s", scaled, symbol)
+	}
+
+	// Fall back to standard formatting
+	return FormatUnit(u)
+}
+
+// prefixedParts splits a unit with a prefixable symbol into the scaled value and
+// the prefixed symbol chosen by policy, e.g. 1500 m into 1.5 and "km". It reports
+// false for units that are written without a prefix.
+func prefixedParts(u Unit, policy PrefixPolicy) (float64, string, bool) {
+	// A kind picks the symbol among units of the same dimension, e.g. N*m over J
+	if lead, rest, ok := kindSymbol(u); ok {
+		prefix, scaled := policy.Choose(lead, u.Value)
+		return scaled, prefix + lead + rest, true
+	}
+
+	// Handle special case for scaled base units. Custom base dimensions count
+	// things such as requests or currency, which read better without prefixes.
+	for i := 0; i < firstCustomIndex; i++ {
+		if isBaseSIUnit(u.Dimension, i) {
+			switch i {
+			case 1:
+				scaled, symbol := massParts(u.Value, "", policy)
+				return scaled, symbol, true
+			case informationIndex:
+				scaled, symbol := informationParts(u.Value, "", policy)
+				return scaled, symbol, true
+			}
+			symbol := baseUnitSymbol(i)
+			prefix, scaled := policy.Choose(symbol, u.Value)
+			return scaled, prefix + symbol, true
 		}
 	}
 
 	// Handle special cases for derived units
-	if u.Dimension == Newton.Dimension {
-		prefix, scaled := computePrefix(u.Value)
-		return fmt.Sprintf("%g %sN", scaled, prefix)
-	} else if u.Dimension == Pascal.Dimension {
-		prefix, scaled := computePrefix(u.Value)
-		return fmt.Sprintf("%g %sPa", scaled, prefix)
-	} else if u.Dimension == Joule.Dimension {
-		prefix, scaled := computePrefix(u.Value)
-		return fmt.Sprintf("%g %sJ", scaled, prefix)
-	} else if u.Dimension == Watt.Dimension {
-		prefix, scaled := computePrefix(u.Value)
-		return fmt.Sprintf("%g %sW", scaled, prefix)
-	} else if u.Dimension == Hertz.Dimension {
-		prefix, scaled := computePrefix(u.Value)
-		return fmt.Sprintf("%g %sHz", scaled, prefix)
-	} else if u.Dimension == Volt.Dimension {
-		prefix, scaled := computePrefix(u.Value)
-		return fmt.Sprintf("%g %sV", scaled, prefix)
+	symbol, ok := standardSymbols[u.Dimension]
+	if !ok {
+		return compoundParts(u, policy)
 	}
 
-	// Fall back to standard formatting
-	return FormatUnit(u)
+	prefix, scaled := policy.Choose(symbol, u.Value)
+	return scaled, prefix + symbol, true
+}
+
+// compoundParts prefixes compound units led by the kilogram or the byte, such as
+// a concentration in kg/m^3 or a data rate in B/s, so that 0.005 kg/m^3 is
+// written as 5 g/m^3
+func compoundParts(u Unit, policy PrefixPolicy) (float64, string, bool) {
+	symbol, err := formatUnitDimension(u)
+	if err != nil {
+		return 0, "", false
+	}
+
+	if num, den := u.Dimension.Exponent(1); num == 1 && den == 1 && leads(symbol, "kg") {
+		scaled, symbol := massParts(u.Value, symbol[len("kg"):], policy)
+		return scaled, symbol, true
+	}
+	if num, den := u.Dimension.Exponent(informationIndex); num == 1 && den == 1 && leads(symbol, "B") {
+		scaled, symbol := informationParts(u.Value, symbol[len("B"):], policy)
+		return scaled, symbol, true
+	}
+	return 0, "", false
+}
+
+// leads reports whether symbol is a compound whose first factor is lead
+func leads(symbol, lead string) bool {
+	return strings.HasPrefix(symbol, lead+"/") || strings.HasPrefix(symbol, lead+"*")
+}
+
+// massParts prefixes a mass in kilograms followed by rest, the remainder of a
+// compound symbol. The kilogram already carries a prefix, so the prefix is
+// chosen for the gram instead, or for the tonne when the policy asks for tonnes.
+func massParts(kilograms float64, rest string, policy PrefixPolicy) (float64, string) {
+	if policy.Steps == NoPrefixes {
+		return kilograms, "kg" + rest
+	}
+	if policy.Tonnes && math.Abs(kilograms) >= 1000 {
+		prefix, scaled := policy.Choose("t", kilograms/1000)
+		return scaled, prefix + "t" + rest
+	}
+
+	// Scale from kilograms in one step, so 1e-9 kg is exactly 1 µg
+	step, _ := policy.chooseStep("g", kilograms*1000)
+	return scaleToStep(kilograms, prefixStep{exp: step.exp - 3}, false), step.symbol + "g" + rest
 }
 
 // extractSimpleValue attempts to extract a simple numeric value from an AST node
@@ -93,44 +162,23 @@ func extractSimpleValue(node Node) (float64, bool) {
 	}
 }
 
-// computePrefix computes the appropriate SI prefix for a value
-func computePrefix(value float64) (string, float64) {
-	absValue := math.Abs(value)
-
-	switch {
-	case absValue == 0:
-		return "", 0
-	case absValue >= 1e9:
-		return "G", value / 1e9
-	case absValue >= 1e6:
-		return "M", value / 1e6
-	case absValue >= 1e3:
-		return "k", value / 1e3
-	case absValue >= 1:
-		return "", value
-	case absValue >= 1e-3:
-		return "m", value * 1e3
-	case absValue >= 1e-6:
-		return "μ", value * 1e6
-	case absValue >= 1e-9:
-		return "n", value * 1e9
-	default:
-		return "p", value * 1e12
-	}
+// isBaseSIUnit checks if a dimension represents a simple base SI unit, the
+// built-in base dimension at index raised to the first power
+func isBaseSIUnit(dim Dimension, index int) bool {
+	return dim == baseDimensionAt(index)
 }
 
-// isBaseSIUnit checks if a dimension represents a simple base SI unit
-func isBaseSIUnit(dim Dimension, index int, exponent int) bool {
-	if exponent != 1 {
-		return false
-	}
-
-	// Check if all other dimensions are zero
-	for i, e := range dim {
-		if i != index && e != 0 {
-			return false
-		}
+// informationParts prefixes an amount of information in bytes followed by rest,
+// the remainder of a compound symbol. The policy is consulted with the whole
+// symbol, so sizes in "B" take binary prefixes by default while rates in "B/s"
+// keep the decimal prefixes usual for bandwidth. Bits are written when the
+// policy asks for them.
+func informationParts(bytes float64, rest string, policy PrefixPolicy) (float64, string) {
+	if policy.Bits {
+		prefix, scaled := policy.Choose("bit"+rest, bytes*8)
+		return scaled, prefix + "bit" + rest
 	}
 
-	return true
+	prefix, scaled := policy.Choose("B"+rest, bytes)
+	return scaled, prefix + "B" + rest
 }
@@ -0,0 +1,263 @@
+package si_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestFormatPrefixBeyondGiga verifies large values use tera and above
+func TestFormatPrefixBeyondGiga(t *testing.T) {
+	if got := si.Watts(5e12).String(); got != "5 TW" {
+		t.Errorf("String = %q, want %q", got, "5 TW")
+	}
+}
+
+// TestFormatPrefixBelowPico verifies small values use femto and below
+func TestFormatPrefixBelowPico(t *testing.T) {
+	if got := si.Joules(3e-15).String(); got != "3 fJ" {
+		t.Errorf("String = %q, want %q", got, "3 fJ")
+	}
+}
+
+// TestFormatPrefixQuetta verifies the 2022 prefixes at the top of the range
+func TestFormatPrefixQuetta(t *testing.T) {
+	if got := si.Meters(2e30).String(); got != "2 Qm" {
+		t.Errorf("String = %q, want %q", got, "2 Qm")
+	}
+}
+
+// TestFormatPrefixQuecto verifies values below the range use the smallest prefix
+func TestFormatPrefixQuecto(t *testing.T) {
+	if got := si.Seconds(5e-33).String(); got != "0.005 qs" {
+		t.Errorf("String = %q, want %q", got, "0.005 qs")
+	}
+}
+
+// TestParseRonnaRonto verifies the 2022 prefixes are accepted by the parser
+func TestParseRonnaRonto(t *testing.T) {
+	got, err := si.ParseUnit("Rm")
+	if err != nil {
+		t.Fatalf("ParseUnit(Rm) error: %v", err)
+	}
+	if got.Value != 1e27 {
+		t.Errorf("Rm value = %v, want 1e27", got.Value)
+	}
+
+	got, err = si.ParseUnit("rs")
+	if err != nil {
+		t.Fatalf("ParseUnit(rs) error: %v", err)
+	}
+	if got.Value != 1e-27 {
+		t.Errorf("rs value = %v, want 1e-27", got.Value)
+	}
+}
+
+// TestPrefixPolicyNoPrefixes verifies the never-prefix policy
+func TestPrefixPolicyNoPrefixes(t *testing.T) {
+	got := si.FormatUnitWithPolicy(si.Meters(1500), si.PrefixPolicy{Steps: si.NoPrefixes})
+	if got != "1500 m" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "1500 m")
+	}
+}
+
+// TestPrefixPolicyAllPrefixes verifies centi is chosen when non-engineering steps are allowed
+func TestPrefixPolicyAllPrefixes(t *testing.T) {
+	got := si.FormatUnitWithPolicy(si.Meters(0.05), si.PrefixPolicy{Steps: si.AllPrefixes})
+	if got != "5 cm" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "5 cm")
+	}
+}
+
+// TestPrefixPolicyExclude verifies excluded prefixes are skipped for the next smaller one
+func TestPrefixPolicyExclude(t *testing.T) {
+	policy := si.PrefixPolicy{Steps: si.AllPrefixes, Exclude: []string{"h", "da", "c"}}
+	got := si.FormatUnitWithPolicy(si.Meters(0.05), policy)
+	if got != "50 mm" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "50 mm")
+	}
+}
+
+// TestPrefixPolicyEngineeringSkipsHecto verifies the default never uses hecto
+func TestPrefixPolicyEngineeringSkipsHecto(t *testing.T) {
+	if got := si.Pascals(500).String(); got != "500 Pa" {
+		t.Errorf("String = %q, want %q", got, "500 Pa")
+	}
+}
+
+// TestPrefixPolicyPreferred verifies a preferred prefix applies regardless of magnitude
+func TestPrefixPolicyPreferred(t *testing.T) {
+	policy := si.PrefixPolicy{Preferred: map[string]string{"Pa": "k"}}
+	got := si.FormatUnitWithPolicy(si.Pascals(250), policy)
+	if got != "0.25 kPa" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "0.25 kPa")
+	}
+}
+
+// TestPrefixPolicyPreferredNone verifies an empty preferred prefix keeps the symbol bare
+func TestPrefixPolicyPreferredNone(t *testing.T) {
+	policy := si.PrefixPolicy{Preferred: map[string]string{"m": ""}}
+	got := si.FormatUnitWithPolicy(si.Meters(42195), policy)
+	if got != "42195 m" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "42195 m")
+	}
+}
+
+// TestPrefixPolicyBinary verifies symbols listed as binary use IEC prefixes
+func TestPrefixPolicyBinary(t *testing.T) {
+	prefix, scaled := si.StandardPrefixPolicy().Choose("B", 1.5*(1<<30))
+	if prefix != "Gi" || scaled != 1.5 {
+		t.Errorf("Choose = %q, %v, want %q, 1.5", prefix, scaled, "Gi")
+	}
+}
+
+// TestPrefixPolicyBinaryBelowOne verifies binary prefixes are not used for fractions
+func TestPrefixPolicyBinaryBelowOne(t *testing.T) {
+	prefix, scaled := si.StandardPrefixPolicy().Choose("B", 0.5)
+	if prefix != "" || scaled != 0.5 {
+		t.Errorf("Choose = %q, %v, want \"\", 0.5", prefix, scaled)
+	}
+}
+
+// TestSetDefaultPrefixPolicy verifies String and MarshalJSON follow the default policy
+func TestSetDefaultPrefixPolicy(t *testing.T) {
+	si.SetDefaultPrefixPolicy(si.PrefixPolicy{Steps: si.NoPrefixes})
+	defer si.SetDefaultPrefixPolicy(si.StandardPrefixPolicy())
+
+	if got := si.Pascals(101325).String(); got != "101325 Pa" {
+		t.Errorf("String = %q, want %q", got, "101325 Pa")
+	}
+	data, err := json.Marshal(si.Pascals(101325))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(data) != `"101325 Pa"` {
+		t.Errorf("Marshal = %s, want %s", data, `"101325 Pa"`)
+	}
+}
+
+// assertMassRoundTrip checks the String form of u and that parsing it gives u back
+func assertMassRoundTrip(t *testing.T, u si.Unit, want string) {
+	t.Helper()
+	got := u.String()
+	if got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+
+	parsed, err := si.Parse(got)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", got, err)
+	}
+	if parsed.Dimension != u.Dimension || math.Abs(parsed.Value-u.Value) > 1e-12*math.Abs(u.Value) {
+		t.Errorf("Parse(%q) = %v, want %v", got, parsed, u)
+	}
+}
+
+// TestFormatMassMegagram verifies a tonne is written in megagrams, not "1 kkg"
+func TestFormatMassMegagram(t *testing.T) {
+	assertMassRoundTrip(t, si.Kilograms(1000), "1 Mg")
+}
+
+// TestFormatMassKilogram verifies kilograms stay unchanged
+func TestFormatMassKilogram(t *testing.T) {
+	assertMassRoundTrip(t, si.Kilograms(2.5), "2.5 kg")
+}
+
+// TestFormatMassGram verifies grams are written without a prefix, not "1 mkg"
+func TestFormatMassGram(t *testing.T) {
+	assertMassRoundTrip(t, si.Grams(1), "1 g")
+}
+
+// TestFormatMassMilligram verifies milligrams
+func TestFormatMassMilligram(t *testing.T) {
+	assertMassRoundTrip(t, si.Grams(0.25), "250 mg")
+}
+
+// TestFormatMassMicrogram verifies micrograms
+func TestFormatMassMicrogram(t *testing.T) {
+	assertMassRoundTrip(t, si.Kilograms(1e-9), "1 μg")
+}
+
+// TestFormatMassConcentration verifies compound units led by mass are prefixed on the gram
+func TestFormatMassConcentration(t *testing.T) {
+	assertMassRoundTrip(t, si.MustParse("12 mg/m^3"), "12 mg/m^3")
+}
+
+// TestFormatMassConcentrationPerLitre verifies mg/L, equal to g/m^3, round-trips
+func TestFormatMassConcentrationPerLitre(t *testing.T) {
+	litre := si.Meter.Pow(3).Mul(si.Scalar(1e-3))
+	assertMassRoundTrip(t, si.Grams(5e-3).Div(litre), "5 g/m^3")
+}
+
+// TestFormatMassTonnes verifies the tonne option
+func TestFormatMassTonnes(t *testing.T) {
+	policy := si.PrefixPolicy{Tonnes: true}
+	if got := si.FormatUnitWithPolicy(si.Tonnes(2.5), policy); got != "2.5 t" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "2.5 t")
+	}
+	if got := si.FormatUnitWithPolicy(si.Tonnes(1500), policy); got != "1.5 kt" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "1.5 kt")
+	}
+	if got := si.FormatUnitWithPolicy(si.Kilograms(500), policy); got != "500 kg" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "500 kg")
+	}
+}
+
+// TestParseTonne verifies the tonne and its prefixes parse
+func TestParseTonne(t *testing.T) {
+	got, err := si.Parse("3 kt")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got.Value != 3e6 || got.Dimension != si.Mass {
+		t.Errorf("Parse = %v, want 3e6 kg", got)
+	}
+}
+
+// TestFormatMassPreferredKilogram verifies masses can be pinned to kilograms
+func TestFormatMassPreferredKilogram(t *testing.T) {
+	policy := si.PrefixPolicy{Preferred: map[string]string{"g": "k"}}
+	if got := si.FormatUnitWithPolicy(si.Kilograms(1500), policy); got != "1500 kg" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "1500 kg")
+	}
+}
+
+// TestFormatMassNoPrefixes verifies the never-prefix policy keeps the kilogram
+func TestFormatMassNoPrefixes(t *testing.T) {
+	got := si.FormatUnitWithPolicy(si.Grams(3), si.PrefixPolicy{Steps: si.NoPrefixes})
+	if got != "0.003 kg" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "0.003 kg")
+	}
+}
+
+// TestFormatBytesBinary verifies sizes in bytes use binary prefixes by default
+func TestFormatBytesBinary(t *testing.T) {
+	if got := si.Gibibytes(1.5).String(); got != "1.5 GiB" {
+		t.Errorf("String = %q, want %q", got, "1.5 GiB")
+	}
+}
+
+// TestFormatByteRateDecimal verifies data rates in bytes keep decimal prefixes
+func TestFormatByteRateDecimal(t *testing.T) {
+	if got := si.MustParse("3 MB/s").String(); got != "3 MB/s" {
+		t.Errorf("String = %q, want %q", got, "3 MB/s")
+	}
+}
+
+// TestFormatBitRate verifies the bits option for bandwidth
+func TestFormatBitRate(t *testing.T) {
+	got := si.FormatUnitWithPolicy(si.MustParse("100 Mbit/s"), si.PrefixPolicy{Bits: true})
+	if got != "100 Mbit/s" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "100 Mbit/s")
+	}
+}
+
+// TestFormatBitRateBinary verifies rates can opt into binary prefixes by their full symbol
+func TestFormatBitRateBinary(t *testing.T) {
+	policy := si.PrefixPolicy{Binary: []string{"B/s"}}
+	if got := si.FormatUnitWithPolicy(si.Mebibytes(4).Div(si.Second), policy); got != "4 MiB/s" {
+		t.Errorf("FormatUnitWithPolicy = %q, want %q", got, "4 MiB/s")
+	}
+}
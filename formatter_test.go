@@ -283,7 +283,7 @@ func TestDimensionToAST(t *testing.T) {
 		},
 		{
 			name:      "velocity",
-			dimension: si.Dimension{1, 0, -1, 0, 0, 0, 0},
+			dimension: si.NewDimension(1, 0, -1, 0, 0, 0, 0),
 			expected:  "m/s",
 		},
 		{
@@ -298,7 +298,7 @@ func TestDimensionToAST(t *testing.T) {
 		},
 		{
 			name:      "mixed dimensions",
-			dimension: si.Dimension{2, 1, -3, 1, -1, 0, 0},
+			dimension: si.NewDimension(2, 1, -3, 1, -1, 0, 0),
 			expected:  "(kg*m^2*A)/(s^3*K)",
 		},
 	}
@@ -332,3 +332,70 @@ func TestDimensionToAST(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatFractionalKnownSymbol verifies a root of a known symbol keeps the symbol
+func TestFormatFractionalKnownSymbol(t *testing.T) {
+	if got := si.FormatUnit(si.Hertz.Sqrt()); got != "Hz^(1/2)" {
+		t.Errorf("FormatUnit = %q, want %q", got, "Hz^(1/2)")
+	}
+}
+
+// TestFormatNoiseDensity verifies a symbol divided by a root of another
+func TestFormatNoiseDensity(t *testing.T) {
+	density := si.Volts(3e-9).Div(si.Hertz.Sqrt())
+	if got := si.FormatUnit(density); got != "3e-09 V/Hz^(1/2)" {
+		t.Errorf("FormatUnit = %q, want %q", got, "3e-09 V/Hz^(1/2)")
+	}
+}
+
+// TestFormatRootSymbols verifies RootSymbols writes square roots as √
+func TestFormatRootSymbols(t *testing.T) {
+	opts := si.DefaultFormatOptions()
+	opts.RootSymbols = true
+
+	density := si.Volts(3e-9).Div(si.Hertz.Sqrt())
+	if got := si.FormatUnitWithOptions(density, &opts); got != "3e-09 V/√Hz" {
+		t.Errorf("FormatUnitWithOptions = %q, want %q", got, "3e-09 V/√Hz")
+	}
+}
+
+// TestFormatFractionalBaseUnit verifies fractional exponents of base units
+func TestFormatFractionalBaseUnit(t *testing.T) {
+	if got := si.FormatUnit(si.Meter.Sqrt()); got != "m^(1/2)" {
+		t.Errorf("FormatUnit = %q, want %q", got, "m^(1/2)")
+	}
+}
+
+// TestFormatFractionalPowerNode verifies PowerNode.Den is honored by FormatAST
+func TestFormatFractionalPowerNode(t *testing.T) {
+	node := &si.PowerNode{Base: &si.IdentNode{Symbol: "s"}, Exp: 3, Den: 2}
+	got, err := si.FormatAST(node, nil)
+	if err != nil {
+		t.Fatalf("FormatAST error: %v", err)
+	}
+	if got != "s^(3/2)" {
+		t.Errorf("FormatAST = %q, want %q", got, "s^(3/2)")
+	}
+}
+
+// TestFormatInPrecision verifies the number of decimals in the target unit
+func TestFormatInPrecision(t *testing.T) {
+	got, err := si.FormatIn(si.Pascals(220000), "psi", 1)
+	if err != nil {
+		t.Fatalf("FormatIn error: %v", err)
+	}
+	if got != "31.9 psi" {
+		t.Errorf("FormatIn = %q, want %q", got, "31.9 psi")
+	}
+}
+
+// TestFormatInZeroPrecision verifies rounding to whole target units
+func TestFormatInZeroPrecision(t *testing.T) {
+	got, err := si.FormatIn(si.Meters(1609.344*2.6), "km", 0)
+	if err != nil {
+		t.Fatalf("FormatIn error: %v", err)
+	}
+	if got != "4 km" {
+		t.Errorf("FormatIn = %q, want %q", got, "4 km")
+	}
+}
@@ -0,0 +1,184 @@
+package si
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatMode selects how the fmt verbs choose the value and symbol of a unit
+type FormatMode int
+
+const (
+	// PrefixMode scales the value with an SI prefix, as String does: "1.5 km"
+	PrefixMode FormatMode = iota
+	// NoPrefixMode keeps the unprefixed symbol: "1500 m", "3600000 J"
+	NoPrefixMode
+	// BaseUnitMode writes the unit in SI base units: "3600000 (kg*m^2)/s^2"
+	BaseUnitMode
+)
+
+// Formatted pairs a unit with a FormatMode for use with the fmt package.
+// It accepts the same verbs as Unit.Format.
+//
+// Example:
+//
+//	fmt.Printf("%.1f\n", si.NoPrefix(si.Kilometers(1.5))) // 1500.0 m
+//	fmt.Printf("%v\n", si.BaseUnits(si.Joules(5)))         // 5 (kg*m^2)/s^2
+type Formatted struct {
+	Unit Unit
+	Mode FormatMode
+}
+
+// NoPrefix formats u without an SI prefix
+func NoPrefix(u Unit) Formatted {
+	return Formatted{Unit: u, Mode: NoPrefixMode}
+}
+
+// BaseUnits formats u in SI base units, without prefix or derived symbols
+func BaseUnits(u Unit) Formatted {
+	return Formatted{Unit: u, Mode: BaseUnitMode}
+}
+
+// Format implements fmt.Formatter so that units work with Printf verbs.
+//
+//	%v, %s   the same as String: "1.5 km"
+//	%.3v     the value with 3 significant digits: "1.23 km"
+//	%e %f %g the value formatted with the verb, flags and precision: "%.2f" gives "1.50 km"
+//	%+v      the SI value and full dimension vector: "1500 [L:1 M:0 T:0 I:0 Θ:0 N:0 J:0]"
+//	%#v      Go syntax: si.Unit{Value:1500, Dimension:si.NewDimension(1), Offset:0}
+//	%q       the String form, quoted
+//
+// Width pads the whole result, on the left unless the - flag is given.
+// Use NoPrefix and BaseUnits to pick a different FormatMode.
+func (u Unit) Format(s fmt.State, verb rune) {
+	Formatted{Unit: u}.Format(s, verb)
+}
+
+// GoString returns the Go syntax of the unit, used by %#v
+func (u Unit) GoString() string {
+	if u.Kind != KindNone {
+		return fmt.Sprintf("si.Unit{Value:%#v, Dimension:%#v, Offset:%#v, Kind:%#v}", u.Value, u.Dimension, u.Offset, u.Kind)
+	}
+	return fmt.Sprintf("si.Unit{Value:%#v, Dimension:%#v, Offset:%#v}", u.Value, u.Dimension, u.Offset)
+}
+
+// String formats the unit with %v in its mode
+func (f Formatted) String() string {
+	return fmt.Sprint(f)
+}
+
+// Format implements fmt.Formatter, see Unit.Format
+func (f Formatted) Format(s fmt.State, verb rune) {
+	var str string
+	switch verb {
+	case 'v', 's', 'q':
+		switch {
+		case verb == 'v' && s.Flag('#'):
+			str = f.Unit.GoString()
+		case verb == 'v' && s.Flag('+'):
+			str = strconv.FormatFloat(f.Unit.Value, 'g', -1, 64) + " " + f.Unit.Dimension.vector()
+			if f.Unit.IsAbsolute() {
+				str += " offset " + strconv.FormatFloat(f.Unit.Offset, 'g', -1, 64)
+			}
+		default:
+			str = f.formatValue(s, 'g')
+		}
+		if verb == 'q' {
+			str = strconv.Quote(str)
+		}
+
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		str = f.formatValue(s, verb)
+
+	default:
+		str = fmt.Sprintf("%%!%c(si.Unit=%s)", verb, f.Unit.String())
+	}
+
+	writePadded(s, str)
+}
+
+// formatValue writes the displayed value with the verb, flags and precision of s,
+// followed by the symbol chosen by the mode
+func (f Formatted) formatValue(s fmt.State, verb rune) string {
+	_, hasPrec := s.Precision()
+	if f.Mode == PrefixMode && verb == 'g' && !hasPrec && !s.Flag('+') && !s.Flag(' ') {
+		// Plain %v keeps the exact String output, including its special cases
+		return f.Unit.String()
+	}
+
+	value, symbol := f.parts()
+
+	var spec strings.Builder
+	spec.WriteByte('%')
+	for _, flag := range "+ #" {
+		if s.Flag(int(flag)) {
+			spec.WriteRune(flag)
+		}
+	}
+	if prec, ok := s.Precision(); ok {
+		spec.WriteString("." + strconv.Itoa(prec))
+	}
+	spec.WriteRune(verb)
+
+	str := fmt.Sprintf(spec.String(), value)
+	if symbol != "" {
+		str += " " + symbol
+	}
+	return str
+}
+
+// parts returns the value and symbol to display in the chosen mode
+func (f Formatted) parts() (float64, string) {
+	u := f.Unit
+
+	if f.Mode != BaseUnitMode {
+		if value, symbol, ok := affineParts(u); ok {
+			return value, symbol
+		}
+	}
+
+	if isPlainNumber(u) {
+		return u.Value, ""
+	}
+
+	switch f.Mode {
+	case PrefixMode:
+		if value, symbol, ok := DefaultDisplayProfile().parts(u); ok {
+			return value, symbol
+		}
+		if scaled, symbol, ok := prefixedParts(u, DefaultPrefixPolicy()); ok {
+			return scaled, symbol
+		}
+	case BaseUnitMode:
+		if node, err := dimensionToAST(u.Dimension); err == nil {
+			if symbol, err := NewDefaultFormatter().Format(node); err == nil {
+				return u.Value, symbol
+			}
+		}
+		return u.Value, formatDimensionFallback(u.Dimension)
+	}
+
+	symbol, err := formatUnitDimension(u)
+	if err != nil {
+		symbol = formatDimensionFallback(u.Dimension)
+	}
+	return u.Value, symbol
+}
+
+// writePadded writes str to s, padded to the width of s
+func writePadded(s fmt.State, str string) {
+	width, ok := s.Width()
+	pad := width - len([]rune(str))
+	if !ok || pad <= 0 {
+		fmt.Fprint(s, str)
+		return
+	}
+
+	padding := strings.Repeat(" ", pad)
+	if s.Flag('-') {
+		fmt.Fprint(s, str+padding)
+		return
+	}
+	fmt.Fprint(s, padding+str)
+}
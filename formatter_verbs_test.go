@@ -0,0 +1,85 @@
+package si_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// assertSprintf checks the result of formatting one argument
+func assertSprintf(t *testing.T, format string, arg interface{}, want string) {
+	t.Helper()
+	if got := fmt.Sprintf(format, arg); got != want {
+		t.Errorf("Sprintf(%q) = %q, want %q", format, got, want)
+	}
+}
+
+// TestFormatVerbV verifies %v and %s match String
+func TestFormatVerbV(t *testing.T) {
+	u := si.Kilometers(1.5)
+	assertSprintf(t, "%v", u, u.String())
+	assertSprintf(t, "%s", u, u.String())
+}
+
+// TestFormatVerbPrecision verifies %.3v limits significant digits
+func TestFormatVerbPrecision(t *testing.T) {
+	assertSprintf(t, "%.3v", si.Meters(1234.5678), "1.23 km")
+}
+
+// TestFormatVerbFloat verifies %f, %e and %g apply to the prefixed value
+func TestFormatVerbFloat(t *testing.T) {
+	u := si.Kilometers(1.5)
+	assertSprintf(t, "%.2f", u, "1.50 km")
+	assertSprintf(t, "%.1e", u, "1.5e+00 km")
+	assertSprintf(t, "%g", u, "1.5 km")
+}
+
+// TestFormatVerbPlusFlag verifies %+f prints the sign of the value
+func TestFormatVerbPlusFlag(t *testing.T) {
+	assertSprintf(t, "%+.1f", si.Kilometers(1.5), "+1.5 km")
+}
+
+// TestFormatVerbPlusV verifies %+v prints the SI value and dimension vector
+func TestFormatVerbPlusV(t *testing.T) {
+	assertSprintf(t, "%+v", si.Newtons(3), "3 [L:1 M:1 T:-2 I:0 Θ:0 N:0 J:0]")
+}
+
+// TestFormatVerbGoSyntax verifies %#v prints Go syntax
+func TestFormatVerbGoSyntax(t *testing.T) {
+	want := "si.Unit{Value:1500, Dimension:si.NewDimension(1), Offset:0}"
+	assertSprintf(t, "%#v", si.Kilometers(1.5), want)
+}
+
+// TestFormatVerbWidth verifies width pads the whole result
+func TestFormatVerbWidth(t *testing.T) {
+	assertSprintf(t, "%8v|", si.Kilometers(1.5), "  1.5 km|")
+	assertSprintf(t, "%-8v|", si.Kilometers(1.5), "1.5 km  |")
+	assertSprintf(t, "%10.2f|", si.Kilometers(1.5), "   1.50 km|")
+}
+
+// TestFormatVerbQuoted verifies %q quotes the String form
+func TestFormatVerbQuoted(t *testing.T) {
+	assertSprintf(t, "%q", si.Kilometers(1.5), `"1.5 km"`)
+}
+
+// TestFormatVerbAffine verifies verbs apply on an absolute temperature's own scale
+func TestFormatVerbAffine(t *testing.T) {
+	assertSprintf(t, "%.1f", si.MustParse("21.46 °C"), "21.5 °C")
+}
+
+// TestFormatVerbBad verifies unsupported verbs are reported like fmt does
+func TestFormatVerbBad(t *testing.T) {
+	assertSprintf(t, "%d", si.Kilometers(1.5), "%!d(si.Unit=1.5 km)")
+}
+
+// TestFormatNoPrefix verifies NoPrefix keeps the unprefixed symbol
+func TestFormatNoPrefix(t *testing.T) {
+	assertSprintf(t, "%.1f", si.NoPrefix(si.Kilometers(1.5)), "1500.0 m")
+	assertSprintf(t, "%v", si.NoPrefix(si.Watts(2000)), "2000 W")
+}
+
+// TestFormatBaseUnits verifies BaseUnits expands derived symbols
+func TestFormatBaseUnits(t *testing.T) {
+	assertSprintf(t, "%v", si.BaseUnits(si.Joules(5)), "5 (kg*m^2)/s^2")
+}
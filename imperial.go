@@ -0,0 +1,99 @@
+package si
+
+import "errors"
+
+// Exact definitional factors of the imperial and US customary units, from the
+// international yard and pound agreement of 1959 and the standard gravity
+const (
+	inchMeters    = 0.0254
+	footMeters    = 12 * inchMeters
+	yardMeters    = 3 * footMeters
+	mileMeters    = 1760 * yardMeters
+	poundKilogram = 0.45359237
+	standardGrav  = 9.80665
+	usGallonM3    = 231 * inchMeters * inchMeters * inchMeters
+	impGallonM3   = 4.54609e-3
+	btuJoules     = 1055.05585262     // International Table BTU
+	psiPascals    = 6894.757293168361 // lbf/in², rounded as "lbf/in^2" evaluates so both agree bit for bit
+)
+
+// ImperialUnits is a UnitPack with the imperial and US customary units:
+// in, ft, yd, mi, lb, oz, gal (US), gal_imp, qt, pt, fl_oz, lbf, BTU, hp, °R,
+// Δ°F for temperature differences, mph, cfm and gpm. Volumes are US liquid measures unless marked imperial.
+// The definitions do not depend on the registry's own units, and °F and psi are
+// already part of the standard ones.
+//
+// Compound units such as ft·lbf or ft/s need no definition of their own.
+//
+// Example:
+//
+//	si.DefaultRegistry().Load(si.ImperialUnits)
+//	torque := si.MustParse("25 ft·lbf") // 33.89544870828501 J
+//	feet, _ := si.ToUnit(si.Meters(100), "ft")
+func ImperialUnits(r *Registry) error {
+	// Prefixing customary units ("kft", "mlb") is never intended
+	none := UnitOptions{Prefixes: PrefixNone}
+	units := []struct {
+		symbol string
+		unit   Unit
+	}{
+		// Length
+		{"in", Meter.Mul(Scalar(inchMeters))},
+		{"ft", Meter.Mul(Scalar(footMeters))},
+		{"yd", Meter.Mul(Scalar(yardMeters))},
+		{"mi", Meter.Mul(Scalar(mileMeters))},
+
+		// Mass
+		{"lb", Kilogram.Mul(Scalar(poundKilogram))},
+		{"oz", Kilogram.Mul(Scalar(poundKilogram / 16))},
+
+		// Volume
+		{"gal", Meter.Pow(3).Mul(Scalar(usGallonM3))},
+		{"gal_imp", Meter.Pow(3).Mul(Scalar(impGallonM3))},
+		{"qt", Meter.Pow(3).Mul(Scalar(usGallonM3 / 4))},
+		{"pt", Meter.Pow(3).Mul(Scalar(usGallonM3 / 8))},
+		{"fl_oz", Meter.Pow(3).Mul(Scalar(usGallonM3 / 128))},
+
+		// Force, energy and power; the BTU is an energy, never a torque
+		{"lbf", Newton.Mul(Scalar(poundKilogram * standardGrav))},
+		{"BTU", ofKind(Joule.Mul(Scalar(btuJoules)), KindEnergy)},
+		{"hp", Watt.Mul(Scalar(550 * footMeters * poundKilogram * standardGrav))}, // mechanical, 550 ft·lbf/s
+
+		// Rankine is an absolute scale, so unlike °F it needs no offset. A
+		// difference in °F is written Δ°F, as °R reads as a temperature.
+		{"°R", Kelvin.Mul(Scalar(5.0 / 9.0))},
+		{"Δ°F", Kelvin.Mul(Scalar(5.0 / 9.0))},
+
+		// Rates
+		{"mph", Meter.Mul(Scalar(mileMeters)).Div(Second.Mul(Scalar(3600)))},
+		{"cfm", Meter.Mul(Scalar(footMeters)).Pow(3).Div(Second.Mul(Scalar(60)))},
+		{"gpm", Meter.Pow(3).Mul(Scalar(usGallonM3)).Div(Second.Mul(Scalar(60)))},
+	}
+
+	var errs []error
+	for _, u := range units {
+		errs = append(errs, r.DefineUnit(u.symbol, u.unit, none))
+	}
+
+	aliases := [][2]string{
+		{"inch", "in"}, {"foot", "ft"}, {"yard", "yd"}, {"mile", "mi"},
+		{"lbm", "lb"}, {"gal_us", "gal"}, {"floz", "fl_oz"}, {"Btu", "BTU"},
+		{"degR", "°R"}, {"delta_degF", "Δ°F"},
+	}
+	for _, a := range aliases {
+		errs = append(errs, r.DefineAlias(a[0], a[1]))
+	}
+	return errors.Join(errs...)
+}
+
+// ToUnit returns the value of u expressed in the unit given by symbol,
+// alongside ToCelsius and ToKiloPascals for any unit the default registry knows.
+// Registry.In does the same with the symbols of another registry.
+//
+// Example:
+//
+//	si.DefaultRegistry().Load(si.ImperialUnits)
+//	feet, _ := si.ToUnit(si.Meters(100), "ft") // 328.0839895013123
+func ToUnit(u Unit, symbol string) (float64, error) {
+	return u.In(symbol)
+}
@@ -0,0 +1,178 @@
+package si_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// imperialRegistry returns a standard registry with the imperial pack loaded
+func imperialRegistry(t *testing.T) *si.Registry {
+	t.Helper()
+	reg := si.NewStandardContext()
+	if err := reg.Load(si.ImperialUnits); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	return reg
+}
+
+// assertImperial parses input with the imperial pack and compares the SI value
+func assertImperial(t *testing.T, input string, want float64) {
+	t.Helper()
+	got, err := imperialRegistry(t).Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", input, err)
+	}
+	if math.Abs(got.Value-want) > 1e-12*math.Abs(want) {
+		t.Errorf("Parse(%q) = %v, want %v", input, got.Value, want)
+	}
+}
+
+// TestImperialLength verifies the yard-based lengths are exact
+func TestImperialLength(t *testing.T) {
+	assertImperial(t, "1 mi", 1609.344)
+	assertImperial(t, "3 ft", 0.9144)
+	assertImperial(t, "12 in", 0.3048)
+}
+
+// TestImperialMass verifies the pound and ounce
+func TestImperialMass(t *testing.T) {
+	assertImperial(t, "1 lb", 0.45359237)
+	assertImperial(t, "16 oz", 0.45359237)
+}
+
+// TestImperialVolume verifies US and imperial gallons differ
+func TestImperialVolume(t *testing.T) {
+	assertImperial(t, "1 gal", 3.785411784e-3)
+	assertImperial(t, "1 gal_imp", 4.54609e-3)
+	assertImperial(t, "4 qt", 3.785411784e-3)
+	assertImperial(t, "128 fl_oz", 3.785411784e-3)
+}
+
+// TestImperialForceAndEnergy verifies lbf, ft·lbf and BTU
+func TestImperialForceAndEnergy(t *testing.T) {
+	assertImperial(t, "1 lbf", 4.4482216152605)
+	assertImperial(t, "1 ft·lbf", 1.3558179483314004)
+	assertImperial(t, "1 BTU", 1055.05585262)
+}
+
+// TestImperialHorsepower verifies mechanical horsepower is 550 ft·lbf/s
+func TestImperialHorsepower(t *testing.T) {
+	assertImperial(t, "1 hp", 745.6998715822702)
+}
+
+// TestImperialRates verifies mph, cfm and gpm
+func TestImperialRates(t *testing.T) {
+	assertImperial(t, "60 mph", 26.8224)
+	assertImperial(t, "1 cfm", 0.028316846592/60)
+	assertImperial(t, "1 gpm", 3.785411784e-3/60)
+}
+
+// TestImperialRankine verifies Rankine is an absolute scale without offset
+func TestImperialRankine(t *testing.T) {
+	assertImperial(t, "491.67 °R", 273.15)
+}
+
+// TestImperialFahrenheitDifference verifies Δ°F is a difference of 5/9 K
+func TestImperialFahrenheitDifference(t *testing.T) {
+	assertImperial(t, "9 Δ°F", 5)
+	assertImperial(t, "9 delta_degF", 5)
+	if u, _ := imperialRegistry(t).Parse("9 Δ°F"); u.IsAbsolute() {
+		t.Error("Expected Δ°F to be a difference")
+	}
+}
+
+// TestImperialNoPrefixes verifies customary units do not take prefixes
+func TestImperialNoPrefixes(t *testing.T) {
+	if _, err := imperialRegistry(t).ParseUnit("kft"); err == nil {
+		t.Error("Expected error for a prefixed foot")
+	}
+}
+
+// TestImperialLoadTwice verifies loading the pack again reports the conflicts
+func TestImperialLoadTwice(t *testing.T) {
+	if err := imperialRegistry(t).Load(si.ImperialUnits); err == nil {
+		t.Error("Expected error for units that are already defined")
+	}
+}
+
+// TestImperialIgnoresDefaultRegistry verifies the pack defines the same units
+// whatever the default registry holds
+func TestImperialIgnoresDefaultRegistry(t *testing.T) {
+	si.SetDefaultRegistry(si.NewRegistry())
+	defer si.SetDefaultRegistry(nil)
+
+	reg := si.NewRegistry()
+	if err := reg.Load(si.ImperialUnits); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	tests := []struct {
+		symbol string
+		want   si.Unit
+	}{
+		{"ft", si.Meter.Mul(si.Scalar(0.3048))},
+		{"lb", si.Kilogram.Mul(si.Scalar(0.45359237))},
+		{"BTU", si.Unit{Value: 1055.05585262, Dimension: si.Joule.Dimension, Kind: si.KindEnergy}},
+	}
+	for _, tt := range tests {
+		got, err := reg.ParseUnit(tt.symbol)
+		if err != nil {
+			t.Fatalf("ParseUnit(%q) error: %v", tt.symbol, err)
+		}
+		if got.Dimension != tt.want.Dimension || got.Kind != tt.want.Kind || math.Abs(got.Value-tt.want.Value) > 1e-12*tt.want.Value {
+			t.Errorf("ParseUnit(%q) = %+v, want %+v", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+// TestFeetWithoutPackIsUnknown verifies "ft" is not read as a femtotonne
+func TestFeetWithoutPackIsUnknown(t *testing.T) {
+	if _, err := si.NewStandardContext().ParseUnit("ft"); err == nil {
+		t.Error("Expected error for ft without the imperial pack")
+	}
+}
+
+// TestToUnit verifies the value of a unit in another symbol
+func TestToUnit(t *testing.T) {
+	si.SetDefaultRegistry(imperialRegistry(t))
+	defer si.SetDefaultRegistry(nil)
+
+	got, err := si.ToUnit(si.Meters(0.9144), "yd")
+	if err != nil {
+		t.Fatalf("ToUnit error: %v", err)
+	}
+	if math.Abs(got-1) > 1e-12 {
+		t.Errorf("ToUnit = %v, want 1", got)
+	}
+}
+
+// TestImperialConstructors verifies the constructors agree with the pack
+func TestImperialConstructors(t *testing.T) {
+	if got := si.Feet(1).Value; got != 0.3048 {
+		t.Errorf("Feet(1) = %v, want 0.3048", got)
+	}
+	if got := si.Pounds(1).Value; got != 0.45359237 {
+		t.Errorf("Pounds(1) = %v, want 0.45359237", got)
+	}
+}
+
+// TestPsiIsPoundForcePerSquareInch verifies psi, Psi and lbf/in² agree exactly
+func TestPsiIsPoundForcePerSquareInch(t *testing.T) {
+	reg := imperialRegistry(t)
+	want, err := reg.Parse("1 lbf/in^2")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if want.Value != 6894.757293168361 {
+		t.Errorf("1 lbf/in^2 = %v Pa, want 6894.757293168361", want.Value)
+	}
+	psi, err := reg.Parse("1 psi")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if psi.Value != want.Value || si.Psi(1).Value != want.Value || si.SymbolicUnits["psi"].Value != want.Value {
+		t.Errorf("psi = %v, Psi(1) = %v, SymbolicUnits = %v, want %v",
+			psi.Value, si.Psi(1).Value, si.SymbolicUnits["psi"].Value, want.Value)
+	}
+}
@@ -0,0 +1,254 @@
+package si
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// JSONMode selects how MarshalJSON writes a unit. UnmarshalJSON reads all of them.
+type JSONMode uint32
+
+const (
+	// JSONString writes the unit as String does, e.g. "101.325 kPa"
+	JSONString JSONMode = iota
+	// JSONValueUnit writes the value in the coherent unit MarshalText uses, e.g.
+	// {"value":101325,"unit":"Pa"}, which is lossless
+	JSONValueUnit
+	// JSONValueDimension writes the value in base SI units with the exponents of
+	// the base dimensions, e.g. {"value":101325,"dimension":{"L":-1,"M":1,"T":-2}}.
+	// The kind is added when set, and absolute temperatures add the zero of their
+	// scale in kelvins, e.g. {"value":294.65,"dimension":{"Θ":1},"offset":273.15}.
+	JSONValueDimension
+	// JSONBaseValue writes the bare value in base SI units, e.g. 101325. The
+	// reader must know the dimension; see UnmarshalJSON.
+	JSONBaseValue
+)
+
+// defaultJSONMode is the mode of Unit.MarshalJSON.
+var defaultJSONMode atomic.Uint32
+
+// DefaultJSONMode returns the mode used by Unit.MarshalJSON, JSONString unless changed
+func DefaultJSONMode() JSONMode {
+	return JSONMode(defaultJSONMode.Load())
+}
+
+// SetDefaultJSONMode replaces the mode used by Unit.MarshalJSON. Use JSONUnit to
+// choose the mode of a single field instead. It is safe to call while other
+// goroutines encode.
+//
+// Example:
+//
+//	si.SetDefaultJSONMode(si.JSONValueUnit)
+//	data, _ := json.Marshal(si.Pascals(101325)) // {"value":101325,"unit":"Pa"}
+func SetDefaultJSONMode(mode JSONMode) {
+	defaultJSONMode.Store(uint32(mode))
+}
+
+// JSONUnit is a unit encoded in a fixed JSONMode, for fields whose encoding
+// should not follow DefaultJSONMode. It decodes every mode, and a bare number
+// keeps the dimension of Unit, so set Unit to the expected unit before decoding.
+//
+// Example:
+//
+//	type Reading struct {
+//	    Pressure si.JSONUnit `json:"pressure"`
+//	}
+//	r := Reading{Pressure: si.JSONUnit{Unit: si.Pascals(101325), Mode: si.JSONBaseValue}}
+//	data, _ := json.Marshal(r) // {"pressure":101325}
+//
+//	decoded := Reading{Pressure: si.JSONUnit{Unit: si.Pascal}}
+//	err := json.Unmarshal(data, &decoded) // decoded.Pressure.Unit is 101325 Pa
+type JSONUnit struct {
+	Unit Unit
+	Mode JSONMode
+}
+
+// MarshalJSON implements json.Marshaler
+func (j JSONUnit) MarshalJSON() ([]byte, error) {
+	return marshalJSON(j.Unit, j.Mode)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (j *JSONUnit) UnmarshalJSON(data []byte) error {
+	return decodeJSON(DefaultRegistry(), data, &j.Unit)
+}
+
+// jsonValueUnit is the object JSONValueUnit writes
+type jsonValueUnit struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// jsonValueDimension is the object JSONValueDimension writes
+type jsonValueDimension struct {
+	Value     float64        `json:"value"`
+	Dimension map[string]any `json:"dimension"`
+	Kind      string         `json:"kind,omitempty"`
+	Offset    float64        `json:"offset,omitempty"`
+}
+
+// jsonObject is either object when decoding
+type jsonObject struct {
+	Value     *float64                   `json:"value"`
+	Unit      *string                    `json:"unit"`
+	Dimension map[string]json.RawMessage `json:"dimension"`
+	Kind      string                     `json:"kind"`
+	Offset    float64                    `json:"offset"`
+}
+
+// marshalJSON encodes the unit in the given mode
+func marshalJSON(u Unit, mode JSONMode) ([]byte, error) {
+	switch mode {
+	case JSONString:
+		return json.Marshal(u.String())
+	case JSONValueUnit:
+		value, symbol, err := textParts(u)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(jsonValueUnit{Value: value, Unit: symbol})
+	case JSONValueDimension:
+		obj := jsonValueDimension{Value: u.Value, Dimension: dimensionExponents(u.Dimension), Offset: u.Offset}
+		if u.Kind != KindNone {
+			obj.Kind = u.Kind.String()
+		}
+		return json.Marshal(obj)
+	case JSONBaseValue:
+		return json.Marshal(u.Value)
+	default:
+		return nil, fmt.Errorf("unknown JSON mode %d", mode)
+	}
+}
+
+// decodeJSON decodes any JSONMode into u, resolving symbols with r. A bare
+// number keeps the dimension and kind u already has.
+func decodeJSON(r *Registry, data []byte, u *Unit) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0:
+		return errors.New("cannot decode empty JSON into Unit")
+	case string(data) == "null":
+		// As for other types, null leaves the unit unchanged
+		return nil
+	case data[0] == '"':
+		var input string
+		if err := json.Unmarshal(data, &input); err != nil {
+			return err
+		}
+		parsed, err := r.Parse(input)
+		if err != nil {
+			return err
+		}
+		*u = parsed
+		return nil
+	case data[0] == '{':
+		return decodeJSONObject(r, data, u)
+	default:
+		var value float64
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*u = Unit{Value: value, Dimension: u.Dimension, Kind: u.Kind}
+		return nil
+	}
+}
+
+// decodeJSONObject decodes the objects of JSONValueUnit and JSONValueDimension
+func decodeJSONObject(r *Registry, data []byte, u *Unit) error {
+	var obj jsonObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if obj.Value == nil {
+		return errors.New(`JSON unit object has no "value"`)
+	}
+
+	switch {
+	case obj.Unit != nil && *obj.Unit == "":
+		*u = Scalar(*obj.Value)
+		return nil
+	case obj.Unit != nil:
+		unit, err := r.ParseUnit(*obj.Unit)
+		if err != nil {
+			return err
+		}
+		*u = applyValue(unit, *obj.Value)
+		return nil
+	case obj.Dimension != nil:
+		dim, err := parseDimensionExponents(obj.Dimension)
+		if err != nil {
+			return err
+		}
+		decoded := Unit{Value: *obj.Value, Dimension: dim, Offset: obj.Offset}
+		if obj.Kind != "" {
+			kind, ok := kindByName(obj.Kind)
+			if !ok {
+				return fmt.Errorf("unknown quantity kind %q", obj.Kind)
+			}
+			if decoded, err = decoded.WithKind(kind); err != nil {
+				return err
+			}
+		}
+		*u = decoded
+		return nil
+	default:
+		return errors.New(`JSON unit object has neither "unit" nor "dimension"`)
+	}
+}
+
+// dimensionExponents maps the symbols of the base dimensions to their exponents,
+// which are numbers, or strings such as "-1/2" for rational exponents
+func dimensionExponents(d Dimension) map[string]any {
+	exponents := make(map[string]any)
+	for i := 0; i < numBaseDimensions; i++ {
+		num, den := d.Exponent(i)
+		switch {
+		case num == 0:
+		case den == 1:
+			exponents[dimensionSymbol(i)] = num
+		default:
+			exponents[dimensionSymbol(i)] = strconv.Itoa(num) + "/" + strconv.Itoa(den)
+		}
+	}
+	return exponents
+}
+
+// parseDimensionExponents reads what dimensionExponents wrote
+func parseDimensionExponents(entries map[string]json.RawMessage) (Dimension, error) {
+	var exps exponents
+	for symbol, raw := range entries {
+		i, ok := dimensionIndex(symbol)
+		if !ok {
+			return Dimension{}, fmt.Errorf("unknown base dimension %q", symbol)
+		}
+
+		var num, den int
+		var fraction string
+		if err := json.Unmarshal(raw, &num); err == nil {
+			den = 1
+		} else if err := json.Unmarshal(raw, &fraction); err == nil && strings.Contains(fraction, "/") {
+			if num, den, err = parseExponent("(" + fraction + ")"); err != nil {
+				return Dimension{}, fmt.Errorf("invalid exponent of %s: %w", symbol, err)
+			}
+		} else {
+			return Dimension{}, fmt.Errorf("invalid exponent of %s: %s", symbol, raw)
+		}
+
+		factor, ok := baseExponents(i, num, den)
+		if !ok {
+			return Dimension{}, fmt.Errorf("exponent of %s out of range", symbol)
+		}
+		exps = exps.mul(factor)
+	}
+
+	dim, ok := exps.pack()
+	if !ok {
+		return Dimension{}, errors.New("dimension exponent out of range")
+	}
+	return dim, nil
+}
@@ -0,0 +1,165 @@
+package si_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestJSONModes verifies the encoding of each mode
+func TestJSONModes(t *testing.T) {
+	pressure := si.Pascals(101325)
+	tests := []struct {
+		mode si.JSONMode
+		want string
+	}{
+		{si.JSONString, `"101.325 kPa"`},
+		{si.JSONValueUnit, `{"value":101325,"unit":"Pa"}`},
+		{si.JSONValueDimension, `{"value":101325,"dimension":{"L":-1,"M":1,"T":-2},"kind":"pressure"}`},
+		{si.JSONBaseValue, `101325`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(si.JSONUnit{Unit: pressure, Mode: tt.mode})
+		if err != nil {
+			t.Fatalf("Marshal(mode %d) error: %v", tt.mode, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("Marshal(mode %d) = %s, want %s", tt.mode, data, tt.want)
+		}
+	}
+}
+
+// TestJSONModesKeepDetails verifies what the object modes write for kinds,
+// temperatures, plain numbers and rational exponents
+func TestJSONModesKeepDetails(t *testing.T) {
+	tests := []struct {
+		unit si.Unit
+		mode si.JSONMode
+		want string
+	}{
+		{si.MustParse("5 kN*m"), si.JSONValueUnit, `{"value":5000,"unit":"N*m"}`},
+		{si.MustParse("21.5 °C"), si.JSONValueUnit, `{"value":21.5,"unit":"°C"}`},
+		{si.Scalar(0.85), si.JSONValueUnit, `{"value":0.85,"unit":""}`},
+		{si.MustParse("5 kN*m"), si.JSONValueDimension, `{"value":5000,"dimension":{"L":2,"M":1,"T":-2},"kind":"torque"}`},
+		{si.MustParse("4 Hz^(1/2)"), si.JSONValueDimension, `{"value":4,"dimension":{"T":"-1/2"}}`},
+		{si.MustParse("21 °C"), si.JSONValueDimension, `{"value":294.15,"dimension":{"Θ":1},"offset":273.15}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(si.JSONUnit{Unit: tt.unit, Mode: tt.mode})
+		if err != nil {
+			t.Fatalf("Marshal(%v) error: %v", tt.unit, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("Marshal(%v, mode %d) = %s, want %s", tt.unit, tt.mode, data, tt.want)
+		}
+	}
+}
+
+// TestJSONModesRoundTrip verifies that Unit.UnmarshalJSON reads every mode
+func TestJSONModesRoundTrip(t *testing.T) {
+	units := []si.Unit{si.Pascals(101325), si.MustParse("5 kN*m"), si.MustParse("3 rad/s"), si.MustParse("4 Hz^(1/2)")}
+	modes := []si.JSONMode{si.JSONString, si.JSONValueUnit, si.JSONValueDimension, si.JSONBaseValue}
+	for _, want := range units {
+		for _, mode := range modes {
+			data, err := json.Marshal(si.JSONUnit{Unit: want, Mode: mode})
+			if err != nil {
+				t.Fatalf("Marshal(%v, mode %d) error: %v", want, mode, err)
+			}
+
+			// A bare number takes the dimension and kind of the unit decoded into
+			got := si.Unit{Dimension: want.Dimension, Kind: want.Kind}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal(%s) error: %v", data, err)
+			}
+			if !got.Equals(want) || got.Kind != want.Kind {
+				t.Errorf("Unmarshal(%s) = %#v, want %#v", data, got, want)
+			}
+		}
+	}
+}
+
+// TestJSONValueDimensionAbsolute verifies that absolute temperatures read back
+// as absolute, and that an object without an offset is a difference
+func TestJSONValueDimensionAbsolute(t *testing.T) {
+	want := si.MustParse("21.5 °C")
+	data, err := json.Marshal(si.JSONUnit{Unit: want, Mode: si.JSONValueDimension})
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	var got si.Unit
+	if err := json.Unmarshal(data, &got); err != nil || !sameUnit(got, want) {
+		t.Errorf("Unmarshal(%s) = %#v, %v, want %#v", data, got, err, want)
+	}
+
+	var rise si.Unit
+	if err := json.Unmarshal([]byte(`{"value":5,"dimension":{"Θ":1}}`), &rise); err != nil || rise.IsAbsolute() {
+		t.Errorf("Unmarshal() = %#v, %v, want a difference of 5 K", rise, err)
+	}
+}
+
+// TestSetDefaultJSONMode verifies that the default mode applies to Unit fields
+func TestSetDefaultJSONMode(t *testing.T) {
+	defer si.SetDefaultJSONMode(si.JSONString)
+	si.SetDefaultJSONMode(si.JSONValueUnit)
+
+	data, err := json.Marshal(struct {
+		Speed si.Unit `json:"speed"`
+	}{si.MustParse("36 km/h")})
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(data) != `{"speed":{"value":10,"unit":"m/s"}}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+// TestJSONUnitBareNumber verifies that a bare number is read in the unit set before decoding
+func TestJSONUnitBareNumber(t *testing.T) {
+	var reading struct {
+		Pressure si.JSONUnit `json:"pressure"`
+	}
+	reading.Pressure.Unit = si.Pascal
+	if err := json.Unmarshal([]byte(`{"pressure":250000}`), &reading); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if !reading.Pressure.Unit.Equals(si.Pascals(250000)) {
+		t.Errorf("Unmarshal() = %v, want 250 kPa", reading.Pressure.Unit)
+	}
+}
+
+// TestJSONNullLeavesUnit verifies that null does not change a unit
+func TestJSONNullLeavesUnit(t *testing.T) {
+	u := si.Meters(3)
+	if err := json.Unmarshal([]byte(`null`), &u); err != nil || !u.Equals(si.Meters(3)) {
+		t.Errorf("Unmarshal(null) = %v, %v, want 3 m", u, err)
+	}
+}
+
+// TestJSONObjectInvalid verifies that malformed objects are rejected
+func TestJSONObjectInvalid(t *testing.T) {
+	inputs := []string{
+		`{"unit":"Pa"}`,
+		`{"value":1}`,
+		`{"value":1,"unit":"parsec per fortnight"}`,
+		`{"value":1,"dimension":{"Q":1}}`,
+		`{"value":1,"dimension":{"L":1.5}}`,
+		`{"value":1,"dimension":{"L":2,"M":1,"T":-2},"kind":"pressure"}`,
+		`{"value":1,"dimension":{"L":1},"kind":"warp"}`,
+		`[1]`,
+	}
+	for _, input := range inputs {
+		var u si.Unit
+		if err := json.Unmarshal([]byte(input), &u); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want error", input)
+		}
+	}
+
+	var u si.Unit
+	err := json.Unmarshal([]byte(`{"value":1,"dimension":{"L":2,"M":1,"T":-2},"kind":"pressure"}`), &u)
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("Unmarshal() of a pressure kind on an energy error = %v, want DimensionMismatchError", err)
+	}
+}
@@ -0,0 +1,262 @@
+package si
+
+import "strconv"
+
+// QuantityKind tells apart quantities that share a dimension, such as torque and
+// energy (both N·m) or frequency and activity (both 1/s). The dimension alone
+// cannot say whether 50 N·m is a torque or 50 J, so a unit may carry a kind that
+// the formatter uses to pick its symbol and that Add, Sub and Compare check.
+//
+// Units without a kind mix freely with any kind. Kinds come from the parsed
+// symbol ("Bq", "Gy", "rpm", "N·m") or from WithKind, and survive arithmetic
+// only where the result is unambiguous: scaling keeps the kind, an angle per
+// time is an angular velocity, an energy per volume an energy density, and so on.
+type QuantityKind uint8
+
+const (
+	// KindNone is the zero value, for units whose kind is not known
+	KindNone QuantityKind = iota
+	// KindEnergy is energy, work or heat, written in J
+	KindEnergy
+	// KindTorque is the moment of a force, written in N*m
+	KindTorque
+	// KindFrequency is the rate of a periodic event, written in Hz
+	KindFrequency
+	// KindActivity is the decay rate of a radionuclide, written in Bq
+	KindActivity
+	// KindAngularVelocity is a rate of rotation, written in rad/s
+	KindAngularVelocity
+	// KindAngle is a plane angle, which the SI treats as dimensionless, written in rad
+	KindAngle
+	// KindAbsorbedDose is energy absorbed per mass of tissue, written in Gy
+	KindAbsorbedDose
+	// KindEquivalentDose is the absorbed dose weighted by its biological effect, written in Sv
+	KindEquivalentDose
+	// KindPressure is force per area, written in Pa
+	KindPressure
+	// KindEnergyDensity is energy stored per volume, written in J/m^3
+	KindEnergyDensity
+	// KindLuminousFlux is the light emitted into a solid angle, written in lm
+	KindLuminousFlux
+	// KindIlluminance is luminous flux per area, written in lx
+	KindIlluminance
+	// KindCatalyticActivity is the rate at which a catalyst converts a substance, written in kat
+	KindCatalyticActivity
+	// KindSolidAngle is a solid angle, which the SI treats as dimensionless, written in sr
+	KindSolidAngle
+)
+
+// kindInfo describes a quantity kind. The symbol is lead followed by rest, and
+// the formatter puts the prefix in front of lead, as in "kN*m".
+type kindInfo struct {
+	name string
+	lead string
+	rest string
+	// dim is the dimension of the kind with angles treated as dimensionless
+	dim Dimension
+	// angle is the angle exponent the kind has in a registry created with
+	// ContextOptions.AngleDimension
+	angle int
+}
+
+// kinds holds the description of each kind, indexed by kind. The dimensions are
+// written out because Mul, which derived units such as Joule are built with, reads this table.
+var kinds = [...]kindInfo{
+	KindNone:              {name: "none"},
+	KindEnergy:            {name: "energy", lead: "J", dim: NewDimension(2, 1, -2)},
+	KindTorque:            {name: "torque", lead: "N", rest: "*m", dim: NewDimension(2, 1, -2)},
+	KindFrequency:         {name: "frequency", lead: "Hz", dim: NewDimension(0, 0, -1)},
+	KindActivity:          {name: "activity", lead: "Bq", dim: NewDimension(0, 0, -1)},
+	KindAngularVelocity:   {name: "angular velocity", lead: "rad", rest: "/s", dim: NewDimension(0, 0, -1), angle: 1},
+	KindAngle:             {name: "angle", lead: "rad", dim: Dimensionless, angle: 1},
+	KindAbsorbedDose:      {name: "absorbed dose", lead: "Gy", dim: NewDimension(2, 0, -2)},
+	KindEquivalentDose:    {name: "equivalent dose", lead: "Sv", dim: NewDimension(2, 0, -2)},
+	KindPressure:          {name: "pressure", lead: "Pa", dim: NewDimension(-1, 1, -2)},
+	KindEnergyDensity:     {name: "energy density", lead: "J", rest: "/m^3", dim: NewDimension(-1, 1, -2)},
+	KindLuminousFlux:      {name: "luminous flux", lead: "lm", dim: NewDimension(0, 0, 0, 0, 0, 0, 1), angle: 2},
+	KindIlluminance:       {name: "illuminance", lead: "lx", dim: NewDimension(-2, 0, 0, 0, 0, 0, 1), angle: 2},
+	KindCatalyticActivity: {name: "catalytic activity", lead: "kat", dim: NewDimension(0, 0, -1, 0, 0, 1)},
+	KindSolidAngle:        {name: "solid angle", lead: "sr", dim: Dimensionless, angle: 2},
+}
+
+// info returns the description of the kind, or that of KindNone for unknown values
+func (k QuantityKind) info() kindInfo {
+	if int(k) >= len(kinds) {
+		return kinds[KindNone]
+	}
+	return kinds[k]
+}
+
+// String returns the name of the kind, such as "torque"
+func (k QuantityKind) String() string {
+	return k.info().name
+}
+
+// GoString returns the Go syntax of the kind, used by %#v
+func (k QuantityKind) GoString() string {
+	names := [...]string{
+		"KindNone", "KindEnergy", "KindTorque", "KindFrequency", "KindActivity",
+		"KindAngularVelocity", "KindAngle", "KindAbsorbedDose", "KindEquivalentDose",
+		"KindPressure", "KindEnergyDensity", "KindLuminousFlux", "KindIlluminance",
+		"KindCatalyticActivity", "KindSolidAngle",
+	}
+	if int(k) < len(names) {
+		return "si." + names[k]
+	}
+	return "si.QuantityKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// kindByName returns the kind whose String is name
+func kindByName(name string) (QuantityKind, bool) {
+	for k, info := range kinds {
+		if info.name == name {
+			return QuantityKind(k), true
+		}
+	}
+	return KindNone, false
+}
+
+// Symbol returns the unit symbol the formatter writes for the kind, such as "N*m"
+// for torque. It is empty for KindNone.
+func (k QuantityKind) Symbol() string {
+	info := k.info()
+	return info.lead + info.rest
+}
+
+// Dimension returns the dimension of the kind, with angles treated as dimensionless
+func (k QuantityKind) Dimension() Dimension {
+	return k.info().dim
+}
+
+// fits reports whether a unit of dimension d can be of this kind. Kinds that
+// involve angles fit both with and without the angle dimension.
+func (k QuantityKind) fits(d Dimension) bool {
+	if k == KindNone {
+		return true
+	}
+	info := k.info()
+	num, den := d.Exponent(angleIndex)
+	if num != 0 && (num != info.angle || den != 1) {
+		return false
+	}
+	return d.withoutAngle() == info.dim
+}
+
+// WithKind returns the unit tagged with the quantity kind, or a
+// DimensionMismatchError if the unit does not have the dimension of the kind.
+// KindNone removes the tag.
+//
+// Example:
+//
+//	work := Newtons(10).Mul(Meters(2))        // 20 J
+//	torque, _ := work.WithKind(KindTorque)    // 20 N*m
+//	_, err := torque.Add(MustParse("5 J"))    // err: cannot add torque and energy
+func (u Unit) WithKind(k QuantityKind) (Unit, error) {
+	if !k.fits(u.Dimension) {
+		return Unit{}, &DimensionMismatchError{Op: "tag", Left: u.Dimension, Right: k.Dimension()}
+	}
+	u.Kind = k
+	return u, nil
+}
+
+// kindSymbol returns the symbol of the unit's kind split into the part that
+// takes a prefix and the rest. It reports false for untagged units.
+func kindSymbol(u Unit) (lead, rest string, ok bool) {
+	if u.Kind == KindNone || !u.Kind.fits(u.Dimension) {
+		return "", "", false
+	}
+	info := u.Kind.info()
+	return info.lead, info.rest, true
+}
+
+// mergeKinds returns the kind of the result of adding or comparing two units of
+// the same dimension. An untagged unit takes the kind of the other one, and two
+// different kinds are a KindMismatchError.
+func mergeKinds(op string, u, v Unit) (QuantityKind, error) {
+	switch {
+	case v.Kind == KindNone || u.Kind == v.Kind:
+		return u.Kind, nil
+	case u.Kind == KindNone:
+		return v.Kind, nil
+	default:
+		return KindNone, &KindMismatchError{Op: op, Left: u.Kind, Right: v.Kind}
+	}
+}
+
+// isPlainNumber reports whether u is an untagged dimensionless factor, which
+// scales a quantity without changing its kind
+func isPlainNumber(u Unit) bool {
+	return u.Kind == KindNone && u.Dimension == Dimensionless
+}
+
+// productKind returns the kind of u times v where it is unambiguous.
+// Mul only calls it when at least one of them has a kind.
+func productKind(u, v Unit, dim Dimension) QuantityKind {
+	var kind QuantityKind
+	switch {
+	case isPlainNumber(v):
+		kind = u.Kind
+	case isPlainNumber(u):
+		kind = v.Kind
+	case u.Kind == KindAngularVelocity && isUntagged(v, TimeDim),
+		v.Kind == KindAngularVelocity && isUntagged(u, TimeDim):
+		kind = KindAngle // θ = ω·t
+	case u.Kind == KindTorque && v.Kind == KindAngle,
+		u.Kind == KindAngle && v.Kind == KindTorque:
+		kind = KindEnergy // W = τ·θ
+	case u.Kind == KindSolidAngle && isUntagged(v, candelaDimension),
+		v.Kind == KindSolidAngle && isUntagged(u, candelaDimension):
+		kind = KindLuminousFlux // Φ = I·Ω
+	}
+	if !kind.fits(dim) {
+		return KindNone
+	}
+	return kind
+}
+
+// quotientKind returns the kind of u divided by v where it is unambiguous.
+// Div only calls it when at least one of them has a kind.
+func quotientKind(u, v Unit, dim Dimension) QuantityKind {
+	var kind QuantityKind
+	switch {
+	case isPlainNumber(v):
+		kind = u.Kind
+	case u.Kind == KindAngle && isUntagged(v, TimeDim):
+		kind = KindAngularVelocity // ω = θ/t
+	case u.Kind == KindEnergy && isUntagged(v, volumeDimension):
+		kind = KindEnergyDensity
+	case u.Kind == KindLuminousFlux && isUntagged(v, areaDimension):
+		kind = KindIlluminance // E = Φ/A
+	case u.Kind == KindEnergy && v.Kind == KindAngle:
+		kind = KindTorque // τ = W/θ
+	}
+	if !kind.fits(dim) {
+		return KindNone
+	}
+	return kind
+}
+
+// volumeDimension is the dimension of m^3, which divides an energy into an energy density
+var volumeDimension = NewDimension(3)
+
+// candelaDimension is the dimension of cd, which a solid angle turns into a luminous flux
+var candelaDimension = NewDimension(0, 0, 0, 0, 0, 0, 1)
+
+// areaDimension is the dimension of m^2, which divides a luminous flux into an illuminance
+var areaDimension = NewDimension(2)
+
+// isUntagged reports whether u is an untagged unit of dimension d
+func isUntagged(u Unit, d Dimension) bool {
+	return u.Kind == KindNone && u.Dimension == d
+}
+
+// isForceTimesLength reports whether a product written in a unit expression is
+// a force times a length, the conventional spelling of torque as in "N·m" or
+// "lbf·ft". Energy is written in joules instead.
+func isForceTimesLength(left, right Unit) bool {
+	if left.Kind != KindNone || right.Kind != KindNone {
+		return false
+	}
+	return left.Dimension == Newton.Dimension && right.Dimension == Length ||
+		left.Dimension == Length && right.Dimension == Newton.Dimension
+}
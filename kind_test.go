@@ -0,0 +1,245 @@
+package si_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestTorqueFormatsAsNewtonMeters verifies N·m is not turned into joules
+func TestTorqueFormatsAsNewtonMeters(t *testing.T) {
+	torque := si.MustParse("5000 N*m")
+	if torque.Kind != si.KindTorque {
+		t.Fatalf("Kind = %v, want torque", torque.Kind)
+	}
+	if got := torque.String(); got != "5 kN*m" {
+		t.Errorf("String = %q, want %q", got, "5 kN*m")
+	}
+	if got := si.MustParse("5 kJ").String(); got != "5 kJ" {
+		t.Errorf("String = %q, want %q", got, "5 kJ")
+	}
+}
+
+// TestKindsWithSharedDimension verifies Hz, Bq, Gy and Sv keep their symbols
+func TestKindsWithSharedDimension(t *testing.T) {
+	for _, input := range []string{"50 Hz", "3 kBq", "2 mGy", "2 mSv", "3 rad/s"} {
+		if got := si.MustParse(input).String(); got != input {
+			t.Errorf("Parse(%q).String() = %q", input, got)
+		}
+	}
+}
+
+// TestUntaggedUnitsKeepDefaultSymbols verifies arithmetic without kinds formats as before
+func TestUntaggedUnitsKeepDefaultSymbols(t *testing.T) {
+	work := si.Newtons(10).Mul(si.Meters(2))
+	if work.Kind != si.KindNone {
+		t.Errorf("Kind = %v, want none", work.Kind)
+	}
+	if got := work.String(); got != "20 J" {
+		t.Errorf("String = %q, want %q", got, "20 J")
+	}
+}
+
+// TestAddTorqueToEnergy verifies quantities of different kinds cannot be added
+func TestAddTorqueToEnergy(t *testing.T) {
+	_, err := si.MustParse("5 N*m").Add(si.MustParse("5 J"))
+	var mismatch *si.KindMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Add error = %v, want KindMismatchError", err)
+	}
+	if mismatch.Left != si.KindTorque || mismatch.Right != si.KindEnergy {
+		t.Errorf("Kinds = %v and %v, want torque and energy", mismatch.Left, mismatch.Right)
+	}
+}
+
+// TestConstantsMatchHelpers verifies the named constants carry the kinds of
+// their helpers, so that both refuse to be added to a torque
+func TestConstantsMatchHelpers(t *testing.T) {
+	pairs := []struct {
+		constant, helper si.Unit
+	}{
+		{si.Joule, si.Joules(1)},
+		{si.Pascal, si.Pascals(1)},
+		{si.Hertz, si.Hertzs(1)},
+		{si.Gray, si.MustParse("1 Gy")},
+		{si.Katal, si.MustParse("1 kat")},
+	}
+	for _, p := range pairs {
+		if p.constant != p.helper {
+			t.Errorf("constant %+v != %+v", p.constant, p.helper)
+		}
+	}
+
+	torque := si.MustParse("5 N*m")
+	var mismatch *si.KindMismatchError
+	if _, err := si.Joule.Add(torque); !errors.As(err, &mismatch) {
+		t.Errorf("Joule.Add(torque) error = %v, want KindMismatchError", err)
+	}
+}
+
+// TestRoundKinds verifies Round checks kinds like Min, Max and Clamp
+func TestRoundKinds(t *testing.T) {
+	var mismatch *si.KindMismatchError
+	if _, err := si.Joules(7).Round(si.MustParse("2 N*m")); !errors.As(err, &mismatch) {
+		t.Errorf("Round(J, N*m) error = %v, want KindMismatchError", err)
+	}
+	if _, err := si.Joules(7).Floor(si.MustParse("2 N*m")); !errors.As(err, &mismatch) {
+		t.Errorf("Floor(J, N*m) error = %v, want KindMismatchError", err)
+	}
+
+	rounded, err := si.Newtons(7).Mul(si.Meters(1)).Round(si.MustParse("2 N*m"))
+	if err != nil {
+		t.Fatalf("Round error: %v", err)
+	}
+	if rounded.Kind != si.KindTorque || rounded.Value != 8 {
+		t.Errorf("Round = %v with kind %v, want 8 N*m torque", rounded.Value, rounded.Kind)
+	}
+}
+
+// TestMeanKinds verifies Mean checks kinds the way Add does and keeps the kind
+func TestMeanKinds(t *testing.T) {
+	var mismatch *si.KindMismatchError
+	if _, err := si.Mean(si.MustParse("5 N*m"), si.MustParse("5 J")); !errors.As(err, &mismatch) {
+		t.Errorf("Mean(N*m, J) error = %v, want KindMismatchError", err)
+	}
+
+	mean, err := si.Mean(si.Newtons(2).Mul(si.Meters(1)), si.MustParse("4 N*m"))
+	if err != nil {
+		t.Fatalf("Mean error: %v", err)
+	}
+	if mean.Kind != si.KindTorque || mean.Value != 3 {
+		t.Errorf("Mean = %v with kind %v, want 3 N*m torque", mean.Value, mean.Kind)
+	}
+}
+
+// TestCompareGrayWithSievert verifies comparisons respect kinds
+func TestCompareGrayWithSievert(t *testing.T) {
+	if _, err := si.MustParse("1 Gy").Compare(si.MustParse("1 Sv")); err == nil {
+		t.Error("Expected error comparing an absorbed dose with an equivalent dose")
+	}
+	if si.MustParse("1 Gy").Equals(si.MustParse("1 Sv")) {
+		t.Error("Gy and Sv should not be equal")
+	}
+}
+
+// TestUntaggedMixesWithAnyKind verifies an untagged unit takes the other kind
+func TestUntaggedMixesWithAnyKind(t *testing.T) {
+	sum, err := si.MustParse("2 Hz").Add(si.Second.Inv())
+	if err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	if sum.Kind != si.KindFrequency {
+		t.Errorf("Kind = %v, want frequency", sum.Kind)
+	}
+}
+
+// TestKindPropagation verifies the kinds that arithmetic derives
+func TestKindPropagation(t *testing.T) {
+	tests := []struct {
+		name string
+		got  si.Unit
+		want si.QuantityKind
+	}{
+		{"scaled torque", si.MustParse("5 N*m").Scale(2), si.KindTorque},
+		{"torque times number", si.MustParse("5 N*m").Mul(si.Scalar(2)), si.KindTorque},
+		{"angle per time", si.MustParse("90 deg").Div(si.Seconds(2)), si.KindAngularVelocity},
+		{"angular velocity times time", si.MustParse("10 rpm").Mul(si.Minutes(1)), si.KindAngle},
+		{"torque times angle", si.MustParse("5 N*m").Mul(si.MustParse("2 rad")), si.KindEnergy},
+		{"energy per volume", si.MustParse("3 J").Div(si.Meter.Pow(3)), si.KindEnergyDensity},
+		{"frequency times time", si.MustParse("50 Hz").Mul(si.Seconds(2)), si.KindNone},
+		{"power", si.MustParse("10 rpm").Mul(si.MustParse("5 N*m")), si.KindNone},
+	}
+	for _, tt := range tests {
+		if tt.got.Kind != tt.want {
+			t.Errorf("%s: Kind = %v, want %v", tt.name, tt.got.Kind, tt.want)
+		}
+	}
+}
+
+// TestWithKind verifies tagging checks the dimension
+func TestWithKind(t *testing.T) {
+	torque, err := si.Joules(20).WithKind(si.KindTorque)
+	if err != nil {
+		t.Fatalf("WithKind error: %v", err)
+	}
+	if got := torque.String(); got != "20 N*m" {
+		t.Errorf("String = %q, want %q", got, "20 N*m")
+	}
+	if _, err := si.Meters(1).WithKind(si.KindTorque); err == nil {
+		t.Error("Expected error tagging a length as torque")
+	}
+}
+
+// TestEnergyDensityIsNotPressure verifies the formatter no longer writes J/m^3 as Pa
+func TestEnergyDensityIsNotPressure(t *testing.T) {
+	density := si.MustParse("2 kJ/m^3")
+	if got := density.String(); got != "2 kJ/m^3" {
+		t.Errorf("String = %q, want %q", got, "2 kJ/m^3")
+	}
+	if got := si.FormatUnit(density); got != "2000 J/m^3" {
+		t.Errorf("FormatUnit = %q, want %q", got, "2000 J/m^3")
+	}
+}
+
+// TestAngleKindFormatsRadians verifies a dimensionless angle keeps its unit
+func TestAngleKindFormatsRadians(t *testing.T) {
+	if got := si.MustParse("2 rad").String(); got != "2 rad" {
+		t.Errorf("String = %q, want %q", got, "2 rad")
+	}
+}
+
+// TestKindGoSyntax verifies %#v includes the kind when set
+func TestKindGoSyntax(t *testing.T) {
+	want := "si.Unit{Value:5, Dimension:si.NewDimension(2, 1, -2), Offset:0, Kind:si.KindTorque}"
+	if got := si.MustParse("5 N*m").GoString(); got != want {
+		t.Errorf("GoString = %q, want %q", got, want)
+	}
+}
+
+// TestKindJSONRoundTrip verifies the kind survives encoding as text
+func TestKindJSONRoundTrip(t *testing.T) {
+	data, err := si.MustParse("3 kBq").MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON error: %v", err)
+	}
+	var u si.Unit
+	if err := u.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON error: %v", err)
+	}
+	if u.Kind != si.KindActivity {
+		t.Errorf("Kind = %v, want activity", u.Kind)
+	}
+}
+
+// TestFormatPhotometricAndCatalyticUnits verifies lm, lx and kat are written with
+// their own symbols and read back with the same kind, while untagged cd, cd/m^2
+// and mol/s keep their base units
+func TestFormatPhotometricAndCatalyticUnits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		kind  si.QuantityKind
+	}{
+		{"100 lm", "100 lm", si.KindLuminousFlux},
+		{"5000 lm", "5 klm", si.KindLuminousFlux},
+		{"50 lx", "50 lx", si.KindIlluminance},
+		{"3 lm/m^2", "3 lx", si.KindIlluminance},
+		{"2 kat", "2 kat", si.KindCatalyticActivity},
+		{"0.002 kat", "2 mkat", si.KindCatalyticActivity},
+		{"5 cd", "5 cd", si.KindNone},
+		{"3 cd/m^2", "3 cd/m^2", si.KindNone},
+		{"2 mol/s", "2 mol/s", si.KindNone},
+	}
+	for _, tt := range tests {
+		u := si.MustParse(tt.input)
+		got := u.String()
+		if got != tt.want || u.Kind != tt.kind {
+			t.Errorf("Parse(%q) = %q of kind %v, want %q of kind %v", tt.input, got, u.Kind, tt.want, tt.kind)
+		}
+		back := si.MustParse(got)
+		if !back.Equals(u) || back.Kind != u.Kind {
+			t.Errorf("Parse(%q) = %v of kind %v, want %v of kind %v", got, back.Value, back.Kind, u.Value, u.Kind)
+		}
+	}
+}
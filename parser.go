@@ -2,6 +2,7 @@ package si
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -12,16 +13,32 @@ type Parser struct {
 	err       error
 }
 
-// NewParser creates a new parser for the input
+// NewParser creates a new parser for the input.
+// A tokenizer error is reported by Parse, so offsets always refer to the input as given.
 func NewParser(input string) *Parser {
-	// Normalize middle dot to asterisk for consistency
-	input = strings.Replace(input, "·", "*", -1)
+	tokenizer := NewTokenizer(input)
+	return &Parser{
+		tokenizer: tokenizer,
+		err:       tokenizer.Err(),
+	}
+}
 
-	// Preprocess for better tokenizing of operators
-	// Add spaces around operators and parentheses for easier tokenization
+// newTokenParser creates a parser over an already tokenized expression.
+// Parse uses it to hand the tokens after the leading number to the unit grammar.
+func newTokenParser(input string, tokens []Token) *Parser {
 	return &Parser{
-		tokenizer: NewTokenizer(input),
+		tokenizer: &Tokenizer{input: input, tokens: tokens},
+	}
+}
+
+// syntaxError records a SyntaxError at the position of token
+func (p *Parser) syntaxError(token Token, format string, args ...interface{}) {
+	offset := token.Pos.Offset
+	if token.Kind == EOF && offset == 0 {
+		// Tokens handed out past the end carry no position
+		offset = len(p.tokenizer.input)
 	}
+	p.err = &SyntaxError{Input: p.tokenizer.input, Offset: offset, Msg: fmt.Sprintf(format, args...)}
 }
 
 // Parse parses a unit expression and returns the AST
@@ -34,7 +51,8 @@ func (p *Parser) Parse() (Node, error) {
 	// Check for unconsumed tokens
 	token := p.tokenizer.Next()
 	if token.Kind != EOF {
-		return nil, fmt.Errorf("unexpected token at end of input: %s", token.Value)
+		p.syntaxError(token, "unexpected token at end of input: %s", token.Value)
+		return nil, p.err
 	}
 
 	return node, nil
@@ -68,6 +86,7 @@ func (p *Parser) parseTerm() Node {
 			Op:    token.Kind,
 			Left:  left,
 			Right: right,
+			Pos:   token.Pos.Offset,
 		}
 	}
 
@@ -90,28 +109,101 @@ func (p *Parser) parsePower() Node {
 	if token.Kind == Power {
 		p.tokenizer.Next() // Consume ^
 
-		// Parse exponent (must be a number)
-		token = p.tokenizer.Next()
-		if token.Kind != Number {
-			p.err = fmt.Errorf("expected number for exponent, got %v", token)
+		num, den := p.parseExponent()
+		if p.err != nil {
 			return nil
 		}
-
-		exp, err := strconv.Atoi(token.Value)
-		if err != nil {
-			p.err = fmt.Errorf("invalid exponent %q: %w", token.Value, err)
+		if abs(num) > maxExponent || den > maxExponent {
+			p.syntaxError(token, "exponent %d/%d out of range", num, den)
 			return nil
 		}
 
-		return &PowerNode{
+		node := &PowerNode{
 			Base: base,
-			Exp:  exp,
+			Exp:  num,
+			Pos:  token.Pos.Offset,
+		}
+		if den != 1 {
+			node.Den = den
 		}
+		return node
 	}
 
 	return base
 }
 
+// parseExponent parses the exponent after ^ as a reduced fraction.
+// Besides integers it accepts decimals such as ^0.5 and parenthesized
+// fractions such as ^(1/2) or ^(-3/2).
+func (p *Parser) parseExponent() (num, den int) {
+	lparen := p.tokenizer.Peek()
+	if lparen.Kind != LParen {
+		return p.parseExponentNumber()
+	}
+	p.tokenizer.Next() // Consume (
+
+	num, den = p.parseExponentNumber()
+	if p.err != nil {
+		return 0, 0
+	}
+
+	if p.tokenizer.Peek().Kind == Divide {
+		p.tokenizer.Next() // Consume /
+
+		divNum, divDen := p.parseExponentNumber()
+		if p.err != nil {
+			return 0, 0
+		}
+		if divNum == 0 {
+			p.syntaxError(lparen, "zero denominator in exponent")
+			return 0, 0
+		}
+		num, den = num*divDen, den*divNum
+	}
+
+	if token := p.tokenizer.Next(); token.Kind != RParen {
+		p.syntaxError(token, "expected ) after exponent, got %v", token)
+		return 0, 0
+	}
+
+	if den < 0 {
+		num, den = -num, -den
+	}
+	g := gcd(abs(num), den)
+	return num / g, den / g
+}
+
+// parseExponentNumber parses a single numeric exponent into an exact fraction,
+// so that ^0.5 means exactly one half rather than a float approximation.
+// Scientific notation such as ^2e3 is rejected.
+func (p *Parser) parseExponentNumber() (num, den int) {
+	token := p.tokenizer.Next()
+	if token.Kind != Number {
+		p.syntaxError(token, "expected number for exponent, got %v", token)
+		return 0, 0
+	}
+	if strings.ContainsAny(token.Value, "eE") {
+		p.syntaxError(token, "invalid exponent %q", token.Value)
+		return 0, 0
+	}
+
+	if n, err := strconv.Atoi(token.Value); err == nil && abs(n) <= maxExponent {
+		return n, 1
+	}
+
+	r, ok := new(big.Rat).SetString(token.Value)
+	if !ok {
+		p.syntaxError(token, "invalid exponent %q", token.Value)
+		return 0, 0
+	}
+	if !r.Num().IsInt64() || !r.Denom().IsInt64() ||
+		abs(int(r.Num().Int64())) > maxExponent || r.Denom().Int64() > maxExponent {
+		p.syntaxError(token, "exponent %s out of range", token.Value)
+		return 0, 0
+	}
+	return int(r.Num().Int64()), int(r.Denom().Int64())
+}
+
 // parseFactor parses an atomic expression or parenthesized expression
 func (p *Parser) parseFactor() Node {
 	if p.err != nil {
@@ -124,13 +216,13 @@ func (p *Parser) parseFactor() Node {
 	case Number:
 		value, err := strconv.ParseFloat(token.Value, 64)
 		if err != nil {
-			p.err = fmt.Errorf("invalid number %q: %w", token.Value, err)
+			p.syntaxError(token, "invalid number %q", token.Value)
 			return nil
 		}
 		return &NumberNode{Value: value}
 
 	case Identifier:
-		return &IdentNode{Symbol: token.Value}
+		return &IdentNode{Symbol: token.Value, Pos: token.Pos.Offset}
 
 	case LParen:
 		expr := p.parseTerm()
@@ -140,23 +232,20 @@ func (p *Parser) parseFactor() Node {
 
 		token = p.tokenizer.Next()
 		if token.Kind != RParen {
-			p.err = fmt.Errorf("expected closing parenthesis, got %v", token)
+			p.syntaxError(token, "expected closing parenthesis, got %v", token)
 			return nil
 		}
 
 		return &GroupNode{Inner: expr}
 
 	default:
-		p.err = fmt.Errorf("unexpected token %v", token)
+		p.syntaxError(token, "unexpected token %v", token)
 		return nil
 	}
 }
 
 // ParseUnitAST parses a unit expression into an AST
 func ParseUnitAST(input string) (Node, error) {
-	// Replace middle dot with asterisk for consistency
-	input = strings.Replace(input, "·", "*", -1)
-
 	parser := NewParser(input)
 	return parser.Parse()
 }
@@ -171,13 +260,11 @@ func EvalAST(node Node, ctx Context) (Unit, error) {
 
 // ParseComplexUnit is the main entry point for parsing a unit expression
 func ParseComplexUnit(input string, ctx Context) (Unit, error) {
-	// Replace middle dot with asterisk for consistency
-	input = strings.Replace(input, "·", "*", -1)
-
 	ast, err := ParseUnitAST(input)
 	if err != nil {
 		return Unit{}, err
 	}
 
-	return EvalAST(ast, ctx)
+	unit, err := EvalAST(ast, ctx)
+	return unit, withInput(err, input)
 }
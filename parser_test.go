@@ -112,12 +112,12 @@ func TestEvalSimpleUnits(t *testing.T) {
 		input string
 		want  Unit
 	}{
-		{"m", Unit{1, Dimension{1, 0, 0, 0, 0, 0, 0}}},
-		{"kg", Unit{1, Dimension{0, 1, 0, 0, 0, 0, 0}}},
-		{"s", Unit{1, Dimension{0, 0, 1, 0, 0, 0, 0}}},
-		{"g", Unit{0.001, Dimension{0, 1, 0, 0, 0, 0, 0}}},
-		{"km", Unit{1000, Dimension{1, 0, 0, 0, 0, 0, 0}}},
-		{"ms", Unit{0.001, Dimension{0, 0, 1, 0, 0, 0, 0}}},
+		{"m", Unit{Value: 1, Dimension: NewDimension(1, 0, 0, 0, 0, 0, 0)}},
+		{"kg", Unit{Value: 1, Dimension: NewDimension(0, 1, 0, 0, 0, 0, 0)}},
+		{"s", Unit{Value: 1, Dimension: NewDimension(0, 0, 1, 0, 0, 0, 0)}},
+		{"g", Unit{Value: 0.001, Dimension: NewDimension(0, 1, 0, 0, 0, 0, 0)}},
+		{"km", Unit{Value: 1000, Dimension: NewDimension(1, 0, 0, 0, 0, 0, 0)}},
+		{"ms", Unit{Value: 0.001, Dimension: NewDimension(0, 0, 1, 0, 0, 0, 0)}},
 	}
 
 	for _, tt := range tests {
@@ -146,34 +146,34 @@ func TestEvalCompoundUnits(t *testing.T) {
 		want  Unit
 	}{
 		// m/s - velocity
-		{"m/s", Unit{1, Dimension{1, 0, -1, 0, 0, 0, 0}}},
+		{"m/s", Unit{Value: 1, Dimension: NewDimension(1, 0, -1, 0, 0, 0, 0)}},
 
 		// km/h - velocity
-		{"km/h", Unit{1000.0 / 3600.0, Dimension{1, 0, -1, 0, 0, 0, 0}}},
+		{"km/h", Unit{Value: 1000.0 / 3600.0, Dimension: NewDimension(1, 0, -1, 0, 0, 0, 0)}},
 
 		// kg*m/s^2 - force (newton)
-		{"kg*m/s^2", Unit{1, Dimension{1, 1, -2, 0, 0, 0, 0}}},
+		{"kg*m/s^2", Unit{Value: 1, Dimension: NewDimension(1, 1, -2, 0, 0, 0, 0)}},
 
 		// N - force
-		{"N", Unit{1, Dimension{1, 1, -2, 0, 0, 0, 0}}},
+		{"N", Unit{Value: 1, Dimension: NewDimension(1, 1, -2, 0, 0, 0, 0)}},
 
 		// J - energy
-		{"J", Unit{1, Dimension{2, 1, -2, 0, 0, 0, 0}}},
+		{"J", Unit{Value: 1, Dimension: NewDimension(2, 1, -2, 0, 0, 0, 0)}},
 
 		// W - power
-		{"W", Unit{1, Dimension{2, 1, -3, 0, 0, 0, 0}}},
+		{"W", Unit{Value: 1, Dimension: NewDimension(2, 1, -3, 0, 0, 0, 0)}},
 
 		// Pa - pressure
-		{"Pa", Unit{1, Dimension{-1, 1, -2, 0, 0, 0, 0}}},
+		{"Pa", Unit{Value: 1, Dimension: NewDimension(-1, 1, -2, 0, 0, 0, 0)}},
 
 		// Complex: (kg*m)/(s^2)
-		{"(kg*m)/(s^2)", Unit{1, Dimension{1, 1, -2, 0, 0, 0, 0}}},
+		{"(kg*m)/(s^2)", Unit{Value: 1, Dimension: NewDimension(1, 1, -2, 0, 0, 0, 0)}},
 
 		// Complex nested: ((kg*m)/s)^2
-		{"((kg*m)/s)^2", Unit{1, Dimension{2, 2, -2, 0, 0, 0, 0}}},
+		{"((kg*m)/s)^2", Unit{Value: 1, Dimension: NewDimension(2, 2, -2, 0, 0, 0, 0)}},
 
 		// Complex: W/(m^2*K^4) - Stefan-Boltzmann constant units
-		{"W/(m^2*K^4)", Unit{1, Dimension{0, 1, -3, 0, -4, 0, 0}}},
+		{"W/(m^2*K^4)", Unit{Value: 1, Dimension: NewDimension(0, 1, -3, 0, -4, 0, 0)}},
 	}
 
 	for _, tt := range tests {
@@ -201,9 +201,9 @@ func TestParseComplexUnit(t *testing.T) {
 		input string
 		want  Unit
 	}{
-		{"m", Unit{1, Dimension{1, 0, 0, 0, 0, 0, 0}}},
-		{"kg*m/s^2", Unit{1, Dimension{1, 1, -2, 0, 0, 0, 0}}},
-		{"km/h", Unit{1000.0 / 3600.0, Dimension{1, 0, -1, 0, 0, 0, 0}}},
+		{"m", Unit{Value: 1, Dimension: NewDimension(1, 0, 0, 0, 0, 0, 0)}},
+		{"kg*m/s^2", Unit{Value: 1, Dimension: NewDimension(1, 1, -2, 0, 0, 0, 0)}},
+		{"km/h", Unit{Value: 1000.0 / 3600.0, Dimension: NewDimension(1, 0, -1, 0, 0, 0, 0)}},
 	}
 
 	for _, tt := range tests {
@@ -240,3 +240,147 @@ func TestParseInvalidUnits(t *testing.T) {
 		})
 	}
 }
+
+// assertTokens checks that tokenizing input yields the expected kinds and values
+func assertTokens(t *testing.T, input string, kinds []TokenKind, values []string) {
+	t.Helper()
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		t.Fatalf("tokenize(%q) error: %v", input, err)
+	}
+	if len(tokens) != len(kinds) {
+		t.Fatalf("tokenize(%q) got %v, want kinds %v", input, tokens, kinds)
+	}
+	for i, token := range tokens {
+		if token.Kind != kinds[i] {
+			t.Errorf("tokenize(%q) token[%d] = %v, want %v", input, i, token.Kind, kinds[i])
+		}
+		if i < len(values) && token.Value != values[i] {
+			t.Errorf("tokenize(%q) token[%d] value = %q, want %q", input, i, token.Value, values[i])
+		}
+	}
+}
+
+// TestTokenizeScientificNotation verifies exponents stay inside the number token
+func TestTokenizeScientificNotation(t *testing.T) {
+	assertTokens(t, "1e-3", []TokenKind{Number, EOF}, []string{"1e-3"})
+	assertTokens(t, "2.5E+6 W", []TokenKind{Number, Identifier, EOF}, []string{"2.5e6", "W"})
+}
+
+// TestTokenizeElectronvoltIsNotExponent verifies "e" without digits starts an identifier
+func TestTokenizeElectronvoltIsNotExponent(t *testing.T) {
+	assertTokens(t, "5eV", []TokenKind{Number, Identifier, EOF}, []string{"5", "eV"})
+}
+
+// TestTokenizeSignedExponent verifies a sign after ^ belongs to the exponent
+func TestTokenizeSignedExponent(t *testing.T) {
+	assertTokens(t, "kg*m^-2",
+		[]TokenKind{Identifier, Multiply, Identifier, Power, Number, EOF},
+		[]string{"kg", "*", "m", "^", "-2"})
+}
+
+// TestTokenizeLeadingSign verifies a sign at the start of input belongs to the number
+func TestTokenizeLeadingSign(t *testing.T) {
+	assertTokens(t, "-40 °F", []TokenKind{Number, Identifier, EOF}, []string{"-40", "°F"})
+}
+
+// TestTokenizeTimesTen verifies "×10^n" and "·10ⁿ" fold into the number
+func TestTokenizeTimesTen(t *testing.T) {
+	assertTokens(t, "1.5×10^3 m", []TokenKind{Number, Identifier, EOF}, []string{"1.5e3", "m"})
+	assertTokens(t, "2 · 10⁻⁶ s", []TokenKind{Number, Identifier, EOF}, []string{"2e-6", "s"})
+}
+
+// TestTokenizeTimesTenOnlyInValue verifies "×10^n" is not folded into an exponent
+func TestTokenizeTimesTenOnlyInValue(t *testing.T) {
+	assertTokens(t, "m^2*10^3",
+		[]TokenKind{Identifier, Power, Number, Multiply, Number, Power, Number, EOF},
+		[]string{"m", "^", "2", "*", "10", "^", "3"})
+}
+
+// TestTokenizeSuperscripts verifies superscript exponents become a power operator
+func TestTokenizeSuperscripts(t *testing.T) {
+	assertTokens(t, "m²", []TokenKind{Identifier, Power, Number, EOF}, []string{"m", "^", "2"})
+	assertTokens(t, "s⁻¹", []TokenKind{Identifier, Power, Number, EOF}, []string{"s", "^", "-1"})
+}
+
+// TestTokenizeGluedNumber verifies a number directly followed by a unit splits cleanly
+func TestTokenizeGluedNumber(t *testing.T) {
+	assertTokens(t, "100km/h",
+		[]TokenKind{Number, Identifier, Divide, Identifier, EOF},
+		[]string{"100", "km", "/", "h"})
+}
+
+// TestTokenizeTimesSign verifies × is a multiplication operator between units
+func TestTokenizeTimesSign(t *testing.T) {
+	assertTokens(t, "N×m", []TokenKind{Identifier, Multiply, Identifier, EOF}, []string{"N", "×", "m"})
+}
+
+// TestTokenizeUnderscoreIdentifier verifies underscores join words within a symbol
+func TestTokenizeUnderscoreIdentifier(t *testing.T) {
+	assertTokens(t, "fl_oz/s", []TokenKind{Identifier, Divide, Identifier, EOF}, []string{"fl_oz", "/", "s"})
+}
+
+// TestTokenizeInvalidNumber verifies malformed numbers are reported
+func TestTokenizeInvalidNumber(t *testing.T) {
+	if _, err := tokenize("1.2.3 m"); err == nil {
+		t.Error("Expected error for malformed number")
+	}
+}
+
+// TestParseFractionalExponent verifies ^(n/d) gives a rational dimension
+func TestParseFractionalExponent(t *testing.T) {
+	got, err := ParseUnit("V/Hz^(1/2)")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+	want := Volt.Div(Hertz.Sqrt())
+	if got.Dimension != want.Dimension {
+		t.Errorf("Dimension = %v, want %v", got.Dimension, want.Dimension)
+	}
+}
+
+// TestParseNegativeFractionalExponent verifies a signed numerator inside ^(n/d)
+func TestParseNegativeFractionalExponent(t *testing.T) {
+	got, err := ParseUnit("s^(-1/2)")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+	if want := Hertz.Sqrt(); got.Dimension != want.Dimension {
+		t.Errorf("Dimension = %v, want %v", got.Dimension, want.Dimension)
+	}
+}
+
+// TestParseDecimalExponent verifies ^0.5 is read as exactly one half
+func TestParseDecimalExponent(t *testing.T) {
+	got, err := ParseUnit("km^0.5")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+	assertUnitAlmostEqual(t, got, Meter.Mul(Scalar(1000)).Sqrt(), "km^0.5")
+}
+
+// TestParseFractionalExponentZeroDenominator verifies ^(1/0) is rejected
+func TestParseFractionalExponentZeroDenominator(t *testing.T) {
+	if _, err := ParseUnit("m^(1/0)"); err == nil {
+		t.Error("Expected error for zero denominator")
+	}
+}
+
+// TestParseExponentTimesTen verifies "m^2*10^3" is a thousand square metres
+func TestParseExponentTimesTen(t *testing.T) {
+	got, err := ParseUnit("m^2*10^3")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+	assertUnitAlmostEqual(t, got, Meter.Pow(2).Mul(Scalar(1000)), "m^2*10^3")
+}
+
+// TestParseScientificExponent verifies exponents in scientific notation are rejected
+func TestParseScientificExponent(t *testing.T) {
+	for _, input := range []string{"m^2e3", "m^(1e1/2)", "s^-2E1"} {
+		if _, err := ParseUnit(input); err == nil {
+			t.Errorf("ParseUnit(%q) succeeded, want error", input)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package si
+
+import (
+	"math"
+	"slices"
+	"sync/atomic"
+)
+
+// PrefixSteps selects the decimal prefixes a PrefixPolicy may choose from
+type PrefixSteps int
+
+const (
+	// EngineeringPrefixes uses the prefixes that are powers of 1000, from quecto (q) to quetta (Q)
+	EngineeringPrefixes PrefixSteps = iota
+	// AllPrefixes also uses hecto (h), deca (da), deci (d) and centi (c)
+	AllPrefixes
+	// NoPrefixes writes every unit without a prefix
+	NoPrefixes
+)
+
+// PrefixPolicy decides which prefix the formatter puts in front of a unit symbol.
+// It is used by FormatUnitWithPrefix, and through it by String and MarshalJSON.
+//
+// Example:
+//
+//	// Pressures always in kPa, masses always in kg, lengths never in hm or dam
+//	policy := si.PrefixPolicy{
+//	    Steps:     si.AllPrefixes,
+//	    Exclude:   []string{"h", "da", "c"},
+//	    Preferred: map[string]string{"Pa": "k", "g": "k"},
+//	}
+//	si.FormatUnitWithPolicy(si.Pascals(250), policy) // 0.25 kPa
+type PrefixPolicy struct {
+	// Steps selects the decimal prefixes to choose from
+	Steps PrefixSteps
+	// Exclude lists prefix symbols that are never chosen, e.g. "h", "da" and "c"
+	Exclude []string
+	// Preferred fixes the prefix of a unit symbol regardless of magnitude.
+	// An empty prefix keeps the symbol unprefixed. Micro is written "μ".
+	Preferred map[string]string
+	// Binary lists unit symbols that take the binary prefixes Ki, Mi, Gi, ... instead.
+	// Data rates are looked up by their whole symbol, so "B/s" must be listed separately.
+	Binary []string
+	// Tonnes writes masses of 1000 kg and above in tonnes (t, kt, Mt, ...) rather than Mg, Gg, ...
+	Tonnes bool
+	// Bits writes information in bits (bit, kbit, Mbit, ...) rather than bytes,
+	// as is usual for data rates
+	Bits bool
+}
+
+// prefixStep is a prefix symbol with the power of its base: 10 for decimal
+// prefixes, 2 for binary ones
+type prefixStep struct {
+	symbol string
+	exp    int
+}
+
+// decimalSteps lists the SI prefixes from largest to smallest, including the
+// unprefixed step, so that the first step not exceeding a value is the one to use
+var decimalSteps = []prefixStep{
+	{"Q", 30}, {"R", 27}, {"Y", 24}, {"Z", 21}, {"E", 18}, {"P", 15},
+	{"T", 12}, {"G", 9}, {"M", 6}, {"k", 3}, {"h", 2}, {"da", 1},
+	{"", 0},
+	{"d", -1}, {"c", -2}, {"m", -3}, {"μ", -6}, {"n", -9}, {"p", -12},
+	{"f", -15}, {"a", -18}, {"z", -21}, {"y", -24}, {"r", -27}, {"q", -30},
+}
+
+// binarySteps lists the binary prefixes from largest to smallest.
+// Values below one are never given a binary prefix.
+var binarySteps = []prefixStep{
+	{"Yi", 80}, {"Zi", 70}, {"Ei", 60}, {"Pi", 50}, {"Ti", 40},
+	{"Gi", 30}, {"Mi", 20}, {"Ki", 10}, {"", 0},
+}
+
+// StandardPrefixPolicy returns the policy used unless SetDefaultPrefixPolicy
+// installs another: engineering steps, with binary prefixes for bytes.
+func StandardPrefixPolicy() PrefixPolicy {
+	return PrefixPolicy{
+		Steps:  EngineeringPrefixes,
+		Binary: []string{"B"},
+	}
+}
+
+// defaultPrefixPolicy is the policy used by FormatUnitWithPrefix.
+var defaultPrefixPolicy atomic.Pointer[PrefixPolicy]
+
+func init() {
+	policy := StandardPrefixPolicy()
+	defaultPrefixPolicy.Store(&policy)
+}
+
+// DefaultPrefixPolicy returns the policy used by FormatUnitWithPrefix, String and MarshalJSON
+func DefaultPrefixPolicy() PrefixPolicy {
+	return *defaultPrefixPolicy.Load()
+}
+
+// SetDefaultPrefixPolicy replaces the policy used by FormatUnitWithPrefix, String and MarshalJSON.
+// The policy should not be modified afterwards. It is safe to call while other
+// goroutines format.
+//
+// Example:
+//
+//	// Plain SI units everywhere, e.g. for machine-readable logs
+//	si.SetDefaultPrefixPolicy(si.PrefixPolicy{Steps: si.NoPrefixes})
+func SetDefaultPrefixPolicy(p PrefixPolicy) {
+	defaultPrefixPolicy.Store(&p)
+}
+
+// Choose returns the prefix for a value expressed in the unprefixed symbol,
+// together with the value scaled to that prefix.
+// Values too small for the smallest allowed prefix use that prefix anyway.
+//
+// Example:
+//
+//	prefix, scaled := si.StandardPrefixPolicy().Choose("W", 5e12) // "T", 5
+func (p PrefixPolicy) Choose(symbol string, value float64) (string, float64) {
+	step, binary := p.chooseStep(symbol, value)
+	return step.symbol, scaleToStep(value, step, binary)
+}
+
+// chooseStep returns the prefix step for the value and whether it is a binary prefix
+func (p PrefixPolicy) chooseStep(symbol string, value float64) (prefixStep, bool) {
+	if p.Steps == NoPrefixes || math.IsNaN(value) || math.IsInf(value, 0) {
+		return prefixStep{}, false
+	}
+
+	binary := slices.Contains(p.Binary, symbol)
+	steps := decimalSteps
+	if binary {
+		steps = binarySteps
+	}
+
+	if prefix, ok := p.Preferred[symbol]; ok {
+		if i := slices.IndexFunc(steps, func(s prefixStep) bool { return s.symbol == prefix }); i >= 0 {
+			return steps[i], binary
+		}
+	}
+
+	if value == 0 {
+		return prefixStep{}, binary
+	}
+
+	// The unprefixed step is always allowed, so last is set whenever the loop ends
+	var last prefixStep
+	for _, s := range steps {
+		if !p.allows(s, binary) {
+			continue
+		}
+		last = s
+		if math.Abs(value) >= stepFactor(s, binary) {
+			return s, binary
+		}
+	}
+	return last, binary
+}
+
+// allows reports whether the policy may choose the step
+func (p PrefixPolicy) allows(s prefixStep, binary bool) bool {
+	if s.symbol == "" {
+		return true
+	}
+	if slices.Contains(p.Exclude, s.symbol) {
+		return false
+	}
+	return binary || p.Steps != EngineeringPrefixes || s.exp%3 == 0
+}
+
+// stepFactor returns the multiplier of a prefix step
+func stepFactor(s prefixStep, binary bool) float64 {
+	if binary {
+		return math.Ldexp(1, s.exp)
+	}
+	return math.Pow10(s.exp)
+}
+
+// scaleToStep expresses value in units of the prefix step.
+// Small prefixes multiply by the exact reciprocal so that 3e-15 becomes 3, not 2.9999999999999996.
+func scaleToStep(value float64, s prefixStep, binary bool) float64 {
+	switch {
+	case binary:
+		return math.Ldexp(value, -s.exp)
+	case s.exp < 0:
+		return value * math.Pow10(-s.exp)
+	default:
+		return value / math.Pow10(s.exp)
+	}
+}
@@ -0,0 +1,211 @@
+// Package quantity adds compile-time dimension checking on top of si.Unit.
+//
+// A Quantity is an si.Unit whose dimension is fixed by its type, so a function
+// taking a Pressure cannot be handed a Power. The types live in their own package
+// because names such as Length, Mass and Power already denote dimensions and
+// token kinds in package si.
+//
+// Example:
+//
+//	func tirePressureOK(p quantity.Pressure) bool {
+//	    limit := quantity.MustNew[quantity.Pressure](35, "psi")
+//	    c, err := p.Compare(limit)
+//	    return err == nil && c <= 0
+//	}
+//
+//	p, err := quantity.Parse[quantity.Pressure]("220 kPa")
+//	tirePressureOK(p)                  // compiles
+//	tirePressureOK(quantity.Power{})   // does not compile
+package quantity
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gurre/si"
+)
+
+// Dim fixes the dimension of a Quantity.
+// It is implemented by the empty marker types in this package, and by your own
+// markers for quantities that are not predefined.
+//
+// Example:
+//
+//	type TorqueDim struct{}
+//
+//	func (TorqueDim) Dimension() si.Dimension { return si.Newton.Mul(si.Meter).Dimension }
+//
+//	type Torque = quantity.Quantity[TorqueDim]
+type Dim interface {
+	Dimension() si.Dimension
+}
+
+// Quantity is an si.Unit whose dimension is D.
+// The zero value is zero in the SI base unit of D.
+type Quantity[D Dim] struct {
+	unit si.Unit
+}
+
+// quantityType is satisfied by every Quantity, which lets the generic functions
+// take the quantity type itself as in Parse[Pressure] rather than its marker.
+type quantityType interface {
+	~struct{ unit si.Unit }
+	Dimension() si.Dimension
+}
+
+// From converts an untyped unit into the quantity type Q.
+// It returns an *si.DimensionMismatchError if the dimensions differ.
+//
+// Example:
+//
+//	power, err := quantity.From[quantity.Power](si.Volts(230).Mul(si.Amperes(10)))
+func From[Q quantityType](u si.Unit) (Q, error) {
+	var q Q
+	if u.Dimension != q.Dimension() {
+		return q, &si.DimensionMismatchError{Op: "convert", Left: u.Dimension, Right: q.Dimension()}
+	}
+	return Q{unit: u}, nil
+}
+
+// MustFrom works like From but panics on error.
+func MustFrom[Q quantityType](u si.Unit) Q {
+	q, err := From[Q](u)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// New creates a quantity from a value expressed in the given unit, so that
+// New[Velocity](36, "km/h") is 10 m/s. Unlike si.New, which expects the value in
+// SI base units, it reports unknown symbols and dimension mismatches as errors.
+//
+// Example:
+//
+//	speed, err := quantity.New[quantity.Velocity](100, "km/h")
+func New[Q quantityType](value float64, symbol string) (Q, error) {
+	u, err := si.ParseUnit(symbol)
+	if err != nil {
+		var q Q
+		return q, err
+	}
+
+	if u.IsAbsolute() {
+		// Affine symbols such as °C place the value on their own scale
+		u.Value = value*(u.Value-u.Offset) + u.Offset
+	} else {
+		u.Value *= value
+	}
+	return From[Q](u)
+}
+
+// MustNew works like New but panics on error.
+func MustNew[Q quantityType](value float64, symbol string) Q {
+	q, err := New[Q](value, symbol)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Parse parses a full expression like "101.3 kPa" into the quantity type Q.
+//
+// Example:
+//
+//	p, err := quantity.Parse[quantity.Pressure]("101.3 kPa")
+func Parse[Q quantityType](input string) (Q, error) {
+	u, err := si.Parse(input)
+	if err != nil {
+		var q Q
+		return q, err
+	}
+	return From[Q](u)
+}
+
+// Dimension returns the dimension fixed by D
+func (q Quantity[D]) Dimension() si.Dimension {
+	var d D
+	return d.Dimension()
+}
+
+// Unit converts the quantity back to an untyped si.Unit, for arithmetic that
+// changes the dimension.
+//
+// Example:
+//
+//	energy := quantity.MustFrom[quantity.Energy](power.Unit().Mul(si.Hours(2)))
+func (q Quantity[D]) Unit() si.Unit {
+	if q.unit.Dimension != q.Dimension() {
+		// The zero value has no dimension stored yet
+		return si.Unit{Dimension: q.Dimension()}
+	}
+	return q.unit
+}
+
+// Value returns the magnitude in SI base units
+func (q Quantity[D]) Value() float64 {
+	return q.unit.Value
+}
+
+// String formats the quantity like si.Unit.String
+func (q Quantity[D]) String() string {
+	return q.Unit().String()
+}
+
+// Format implements fmt.Formatter with the verbs of si.Unit.Format
+func (q Quantity[D]) Format(s fmt.State, verb rune) {
+	q.Unit().Format(s, verb)
+}
+
+// Add returns the sum of two quantities of the same type, following si.Unit.Add:
+// a difference added to an absolute temperature keeps its scale, while adding
+// two absolute temperatures, or an energy to a torque, is an error.
+//
+// Example:
+//
+//	room := quantity.MustNew[quantity.Temperature](20, "°C")
+//	warmer, err := room.Add(quantity.MustNew[quantity.Temperature](5, "K")) // 25 °C
+func (q Quantity[D]) Add(o Quantity[D]) (Quantity[D], error) {
+	u, err := q.Unit().Add(o.Unit())
+	return Quantity[D]{unit: u}, err
+}
+
+// Sub returns the difference of two quantities of the same type, following
+// si.Unit.Sub: two absolute temperatures give a difference in kelvins.
+func (q Quantity[D]) Sub(o Quantity[D]) (Quantity[D], error) {
+	u, err := q.Unit().Sub(o.Unit())
+	return Quantity[D]{unit: u}, err
+}
+
+// Scale multiplies the quantity by a plain number, keeping its kind.
+// Like si.Unit.Scale, the result is a difference rather than an absolute temperature.
+func (q Quantity[D]) Scale(factor float64) Quantity[D] {
+	return Quantity[D]{unit: q.Unit().Scale(factor)}
+}
+
+// Compare returns -1, 0, 1 if q <, ==, > o respectively, following
+// si.Unit.Compare: both sides share a dimension, but comparing an energy to a
+// torque returns an *si.KindMismatchError.
+func (q Quantity[D]) Compare(o Quantity[D]) (int, error) {
+	return q.Unit().Compare(o.Unit())
+}
+
+// MarshalJSON encodes the quantity like si.Unit, e.g. "101.3 kPa"
+func (q Quantity[D]) MarshalJSON() ([]byte, error) {
+	return q.Unit().MarshalJSON()
+}
+
+// UnmarshalJSON decodes a unit string and checks that it has the dimension D.
+// Decoding "5 kW" into a Pressure returns an *si.DimensionMismatchError.
+// A bare number is read in the base SI unit of D.
+func (q *Quantity[D]) UnmarshalJSON(data []byte) error {
+	u := si.Unit{Dimension: q.Dimension()}
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if u.Dimension != q.Dimension() {
+		return &si.DimensionMismatchError{Op: "decode", Left: u.Dimension, Right: q.Dimension()}
+	}
+	q.unit = u
+	return nil
+}
@@ -0,0 +1,222 @@
+package quantity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+	"github.com/gurre/si/quantity"
+)
+
+// TestParse verifies parsing into a matching quantity type
+func TestParse(t *testing.T) {
+	p, err := quantity.Parse[quantity.Pressure]("101.3 kPa")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if math.Abs(p.Value()-101300) > 1e-9 {
+		t.Errorf("Value = %v, want 101300", p.Value())
+	}
+}
+
+// TestParseDimensionMismatch verifies parsing into the wrong quantity type fails
+func TestParseDimensionMismatch(t *testing.T) {
+	_, err := quantity.Parse[quantity.Pressure]("5 kW")
+
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected *si.DimensionMismatchError, got %v", err)
+	}
+	if mismatch.Right != si.Pascal.Dimension {
+		t.Errorf("Right = %v, want %v", mismatch.Right, si.Pascal.Dimension)
+	}
+}
+
+// TestParseUnknownUnit verifies parse errors pass through unchanged
+func TestParseUnknownUnit(t *testing.T) {
+	var unknown *si.UnknownUnitError
+	if _, err := quantity.Parse[quantity.Length]("3 furlong"); !errors.As(err, &unknown) {
+		t.Errorf("Expected *si.UnknownUnitError, got %v", err)
+	}
+}
+
+// TestNew verifies construction from a value and symbol
+func TestNew(t *testing.T) {
+	v, err := quantity.New[quantity.Velocity](36, "km/h")
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	if math.Abs(v.Value()-10) > 1e-9 {
+		t.Errorf("Value = %v, want 10", v.Value())
+	}
+}
+
+// TestNewUnknownSymbol verifies New reports unknown symbols instead of falling back to a scalar
+func TestNewUnknownSymbol(t *testing.T) {
+	if _, err := quantity.New[quantity.Length](3, "furlong"); err == nil {
+		t.Error("Expected error for unknown symbol")
+	}
+}
+
+// TestFromRoundTrip verifies conversion to and from si.Unit
+func TestFromRoundTrip(t *testing.T) {
+	u := si.Volts(230).Mul(si.Amperes(10))
+	p, err := quantity.From[quantity.Power](u)
+	if err != nil {
+		t.Fatalf("From error: %v", err)
+	}
+	if p.Unit() != u {
+		t.Errorf("Unit = %v, want %v", p.Unit(), u)
+	}
+}
+
+// TestMustFromPanics verifies MustFrom panics on a dimension mismatch
+func TestMustFromPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic")
+		}
+	}()
+	quantity.MustFrom[quantity.Energy](si.Watts(1))
+}
+
+// TestZeroValue verifies the zero value carries its dimension
+func TestZeroValue(t *testing.T) {
+	var e quantity.Energy
+	if e.Unit().Dimension != si.Joule.Dimension {
+		t.Errorf("Dimension = %v, want %v", e.Unit().Dimension, si.Joule.Dimension)
+	}
+}
+
+// TestArithmetic verifies same-type arithmetic keeps the type
+func TestArithmetic(t *testing.T) {
+	a := quantity.MustFrom[quantity.Length](si.Meters(3))
+	b := quantity.MustFrom[quantity.Length](si.Meters(2))
+
+	if got, err := a.Add(b); err != nil || got.Value() != 5 {
+		t.Errorf("Add = %v, %v, want 5", got, err)
+	}
+	if got, err := a.Sub(b); err != nil || got.Value() != 1 {
+		t.Errorf("Sub = %v, %v, want 1", got, err)
+	}
+	if got := a.Scale(2).Value(); got != 6 {
+		t.Errorf("Scale = %v, want 6", got)
+	}
+	if c, err := a.Compare(b); err != nil || c != 1 {
+		t.Errorf("Compare = %d, %v, want 1", c, err)
+	}
+}
+
+// TestArithmeticKeepsKindAndOffset verifies arithmetic follows the rules of si.Unit
+func TestArithmeticKeepsKindAndOffset(t *testing.T) {
+	room := quantity.MustNew[quantity.Temperature](20, "°C")
+	warmer, err := room.Add(quantity.MustNew[quantity.Temperature](5, "K"))
+	if err != nil || warmer.String() != "25 °C" {
+		t.Errorf("Add = %v, %v, want 25 °C", warmer, err)
+	}
+	if rise, err := warmer.Sub(room); err != nil || rise.String() != "5 K" {
+		t.Errorf("Sub = %v, %v, want 5 K", rise, err)
+	}
+	var absolute *si.AbsoluteTemperatureError
+	if _, err := room.Add(warmer); !errors.As(err, &absolute) {
+		t.Errorf("Add of two absolute temperatures error = %v, want *si.AbsoluteTemperatureError", err)
+	}
+
+	torque := quantity.MustFrom[quantity.Energy](si.MustParse("5 N*m"))
+	if got := torque.Scale(2); got.Unit().Kind != si.KindTorque || got.String() != "10 N*m" {
+		t.Errorf("Scale = %v (%v), want 10 N*m", got, got.Unit().Kind)
+	}
+	var mismatch *si.KindMismatchError
+	if _, err := torque.Add(quantity.MustNew[quantity.Energy](5, "J")); !errors.As(err, &mismatch) {
+		t.Errorf("Add of a torque and an energy error = %v, want *si.KindMismatchError", err)
+	}
+	if _, err := quantity.MustNew[quantity.Energy](5, "N*m").Compare(quantity.MustNew[quantity.Energy](100, "J")); !errors.As(err, &mismatch) {
+		t.Errorf("Compare of a torque and an energy error = %v, want *si.KindMismatchError", err)
+	}
+}
+
+// TestDerivedQuantity verifies leaving and re-entering the typed layer
+func TestDerivedQuantity(t *testing.T) {
+	power := quantity.MustNew[quantity.Power](2, "kW")
+	energy, err := quantity.From[quantity.Energy](power.Unit().Mul(si.Hours(1)))
+	if err != nil {
+		t.Fatalf("From error: %v", err)
+	}
+	if math.Abs(energy.Value()-7.2e6) > 1e-6 {
+		t.Errorf("Value = %v, want 7.2e6", energy.Value())
+	}
+}
+
+// TestJSONRoundTrip verifies a quantity inside a struct round-trips through JSON
+func TestJSONRoundTrip(t *testing.T) {
+	type reading struct {
+		Pressure quantity.Pressure `json:"pressure"`
+	}
+
+	in := reading{Pressure: quantity.MustNew[quantity.Pressure](101.3, "kPa")}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var out reading
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if math.Abs(out.Pressure.Value()-in.Pressure.Value()) > 1e-9 {
+		t.Errorf("Value = %v, want %v", out.Pressure.Value(), in.Pressure.Value())
+	}
+}
+
+// TestUnmarshalJSONDimensionMismatch verifies decoding checks the dimension
+func TestUnmarshalJSONDimensionMismatch(t *testing.T) {
+	var p quantity.Pressure
+	err := json.Unmarshal([]byte(`"5 kW"`), &p)
+
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("Expected *si.DimensionMismatchError, got %v", err)
+	}
+}
+
+// TestString verifies formatting matches si.Unit
+func TestString(t *testing.T) {
+	p := quantity.MustNew[quantity.Pressure](101.3, "kPa")
+	if p.String() != p.Unit().String() {
+		t.Errorf("String = %q, want %q", p.String(), p.Unit().String())
+	}
+}
+
+// TestNewAffine verifies New places values on an affine scale
+func TestNewAffine(t *testing.T) {
+	temp, err := quantity.New[quantity.Temperature](25, "°C")
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	if math.Abs(temp.Value()-298.15) > 1e-9 {
+		t.Errorf("Value = %v, want 298.15", temp.Value())
+	}
+}
+
+// TestParseDataRate verifies data rates are a quantity of their own
+func TestParseDataRate(t *testing.T) {
+	if _, err := quantity.Parse[quantity.DataRate]("100 Mbit/s"); err != nil {
+		t.Errorf("Parse error: %v", err)
+	}
+	if _, err := quantity.Parse[quantity.DataRate]("100 Hz"); err == nil {
+		t.Error("Expected error for a frequency")
+	}
+}
+
+// TestUnmarshalJSONBareNumber verifies that a bare number is read in base SI units
+func TestUnmarshalJSONBareNumber(t *testing.T) {
+	var p quantity.Pressure
+	if err := json.Unmarshal([]byte(`101325`), &p); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !p.Unit().Equals(si.Pascals(101325)) {
+		t.Errorf("Unmarshal = %v, want 101.325 kPa", p)
+	}
+}
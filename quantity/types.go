@@ -0,0 +1,111 @@
+package quantity
+
+import "github.com/gurre/si"
+
+// Predefined quantity types. Each is an alias of Quantity with the marker below,
+// so functions can be written against either name.
+type (
+	Length       = Quantity[LengthDim]
+	Mass         = Quantity[MassDim]
+	Time         = Quantity[TimeDim]
+	Current      = Quantity[CurrentDim]
+	Temperature  = Quantity[TemperatureDim]
+	Area         = Quantity[AreaDim]
+	Volume       = Quantity[VolumeDim]
+	Velocity     = Quantity[VelocityDim]
+	Acceleration = Quantity[AccelerationDim]
+	Force        = Quantity[ForceDim]
+	Pressure     = Quantity[PressureDim]
+	Energy       = Quantity[EnergyDim]
+	Power        = Quantity[PowerDim]
+	Frequency    = Quantity[FrequencyDim]
+	Charge       = Quantity[ChargeDim]
+	Voltage      = Quantity[VoltageDim]
+	Information  = Quantity[InformationDim]
+	DataRate     = Quantity[DataRateDim]
+)
+
+// Dimensions of the derived quantities, computed once from the si units
+var (
+	areaDim         = si.Meter.Pow(2).Dimension
+	volumeDim       = si.Meter.Pow(3).Dimension
+	velocityDim     = si.Meter.Div(si.Second).Dimension
+	accelerationDim = si.Meter.Div(si.Second.Pow(2)).Dimension
+	dataRateDim     = si.Bytes(1).Div(si.Second).Dimension
+)
+
+// Marker types fixing the dimension of the predefined quantities
+type (
+	LengthDim       struct{}
+	MassDim         struct{}
+	TimeDim         struct{}
+	CurrentDim      struct{}
+	TemperatureDim  struct{}
+	AreaDim         struct{}
+	VolumeDim       struct{}
+	VelocityDim     struct{}
+	AccelerationDim struct{}
+	ForceDim        struct{}
+	PressureDim     struct{}
+	EnergyDim       struct{}
+	PowerDim        struct{}
+	FrequencyDim    struct{}
+	ChargeDim       struct{}
+	VoltageDim      struct{}
+	InformationDim  struct{}
+	DataRateDim     struct{}
+)
+
+// Dimension implements Dim
+func (LengthDim) Dimension() si.Dimension { return si.Length }
+
+// Dimension implements Dim
+func (MassDim) Dimension() si.Dimension { return si.Mass }
+
+// Dimension implements Dim
+func (TimeDim) Dimension() si.Dimension { return si.TimeDim }
+
+// Dimension implements Dim
+func (CurrentDim) Dimension() si.Dimension { return si.Current }
+
+// Dimension implements Dim
+func (TemperatureDim) Dimension() si.Dimension { return si.Temperature }
+
+// Dimension implements Dim
+func (AreaDim) Dimension() si.Dimension { return areaDim }
+
+// Dimension implements Dim
+func (VolumeDim) Dimension() si.Dimension { return volumeDim }
+
+// Dimension implements Dim
+func (VelocityDim) Dimension() si.Dimension { return velocityDim }
+
+// Dimension implements Dim
+func (AccelerationDim) Dimension() si.Dimension { return accelerationDim }
+
+// Dimension implements Dim
+func (ForceDim) Dimension() si.Dimension { return si.Newton.Dimension }
+
+// Dimension implements Dim
+func (PressureDim) Dimension() si.Dimension { return si.Pascal.Dimension }
+
+// Dimension implements Dim
+func (EnergyDim) Dimension() si.Dimension { return si.Joule.Dimension }
+
+// Dimension implements Dim
+func (PowerDim) Dimension() si.Dimension { return si.Watt.Dimension }
+
+// Dimension implements Dim
+func (FrequencyDim) Dimension() si.Dimension { return si.Hertz.Dimension }
+
+// Dimension implements Dim
+func (ChargeDim) Dimension() si.Dimension { return si.Coulomb.Dimension }
+
+// Dimension implements Dim
+func (VoltageDim) Dimension() si.Dimension { return si.Volt.Dimension }
+
+// Dimension implements Dim
+func (InformationDim) Dimension() si.Dimension { return si.Information }
+
+// Dimension implements Dim
+func (DataRateDim) Dimension() si.Dimension { return dataRateDim }
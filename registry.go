@@ -0,0 +1,510 @@
+package si
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PrefixRule selects which prefixes may be attached to a unit symbol.
+// Restricting prefixes keeps ambiguous spellings such as "kkg" or "kh" from
+// silently resolving to something nobody meant.
+type PrefixRule int
+
+const (
+	// PrefixDecimal allows the SI decimal prefixes (k, M, m, µ, ...).
+	// It is the zero value so that units defined without options behave like SI units.
+	PrefixDecimal PrefixRule = iota
+	// PrefixNone forbids any prefix; the symbol only resolves verbatim.
+	PrefixNone
+	// PrefixBinary allows only the binary prefixes (Ki, Mi, Gi, ...).
+	PrefixBinary
+	// PrefixAll allows both decimal and binary prefixes.
+	PrefixAll
+)
+
+// UnitOptions controls how a symbol registered with DefineUnit is resolved.
+type UnitOptions struct {
+	// Prefixes selects the class of prefixes that may be attached to the symbol.
+	Prefixes PrefixRule
+	// Only, when non-empty, further restricts prefixing to the listed prefix symbols.
+	// For example the tonne is commonly written kt and Mt but never mt.
+	Only []string
+}
+
+// allows reports whether the options permit the given prefix.
+func (o UnitOptions) allows(prefix string, p prefixDef) bool {
+	switch o.Prefixes {
+	case PrefixNone:
+		return false
+	case PrefixDecimal:
+		if p.binary {
+			return false
+		}
+	case PrefixBinary:
+		if !p.binary {
+			return false
+		}
+	}
+
+	if len(o.Only) == 0 {
+		return true
+	}
+	for _, allowed := range o.Only {
+		if allowed == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// registeredUnit is a unit definition together with its resolution options.
+type registeredUnit struct {
+	unit Unit
+	opts UnitOptions
+}
+
+// prefixDef is a registered prefix scaling factor.
+// Binary prefixes are tracked separately so that PrefixRule can tell them apart.
+type prefixDef struct {
+	factor float64
+	binary bool
+}
+
+// Registry holds the unit symbols, prefixes and aliases the parser resolves.
+// It implements Context, so it can be passed to ParseComplexUnit and EvalAST directly.
+// A Registry is safe for concurrent use; definitions may be added while other
+// goroutines are parsing.
+//
+// Example:
+//
+//	reg := NewStandardContext()
+//	reg.DefineUnit("Torr", Pascals(101325.0/760), UnitOptions{})
+//	reg.DefineAlias("torr", "Torr")
+//	p, _ := reg.Parse("760 mTorr") // 101.325 Pa
+type Registry struct {
+	mu             sync.RWMutex
+	units          map[string]registeredUnit
+	aliases        map[string]string
+	prefixes       map[string]prefixDef
+	sortedPrefixes []string
+}
+
+// NewRegistry creates an empty registry with no units or prefixes.
+// Use NewStandardContext for a registry preloaded with the SI units.
+func NewRegistry() *Registry {
+	return &Registry{
+		units:    make(map[string]registeredUnit),
+		aliases:  make(map[string]string),
+		prefixes: make(map[string]prefixDef),
+	}
+}
+
+// DefineUnit registers a unit symbol. The unit's Value is the scale of one
+// symbol in SI base units, e.g. Pascals(1e5) for "bar".
+// Returns an error if the symbol is empty, already defined, or has a zero scale.
+func (r *Registry) DefineUnit(symbol string, u Unit, opts UnitOptions) error {
+	if symbol == "" {
+		return fmt.Errorf("cannot define unit with empty symbol")
+	}
+	if u.Value == 0 {
+		return fmt.Errorf("cannot define unit %s with zero scale", symbol)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.definedLocked(symbol) {
+		return fmt.Errorf("unit %s is already defined", symbol)
+	}
+	r.units[symbol] = registeredUnit{unit: u, opts: opts}
+	return nil
+}
+
+// UnitPack defines a group of related units in a registry, such as ImperialUnits
+type UnitPack func(r *Registry) error
+
+// Load defines the units of each pack in the registry.
+// Errors from all packs are joined, so one conflicting symbol does not keep the
+// remaining units of a pack from being defined.
+//
+// Example:
+//
+//	si.DefaultRegistry().Load(si.ImperialUnits)
+//	speed := si.MustParse("65 mph")
+func (r *Registry) Load(packs ...UnitPack) error {
+	var errs []error
+	for _, pack := range packs {
+		errs = append(errs, pack(r))
+	}
+	return errors.Join(errs...)
+}
+
+// DefinePrefix registers a decimal prefix such as "k" (1e3).
+// Returns an error if the prefix is empty, already defined, or has a zero factor.
+func (r *Registry) DefinePrefix(symbol string, factor float64) error {
+	return r.definePrefix(symbol, prefixDef{factor: factor})
+}
+
+// DefineBinaryPrefix registers a binary prefix such as "Ki" (1024).
+// Binary prefixes only attach to units defined with PrefixBinary or PrefixAll.
+func (r *Registry) DefineBinaryPrefix(symbol string, factor float64) error {
+	return r.definePrefix(symbol, prefixDef{factor: factor, binary: true})
+}
+
+// definePrefix stores a prefix and keeps the longest-first ordering used by Resolve.
+func (r *Registry) definePrefix(symbol string, p prefixDef) error {
+	if symbol == "" {
+		return fmt.Errorf("cannot define prefix with empty symbol")
+	}
+	if p.factor == 0 {
+		return fmt.Errorf("cannot define prefix %s with zero factor", symbol)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.prefixes[symbol]; ok {
+		return fmt.Errorf("prefix %s is already defined", symbol)
+	}
+	r.prefixes[symbol] = p
+
+	// Longest prefixes must be tried first so that "da" wins over "d"
+	r.sortedPrefixes = append(r.sortedPrefixes, symbol)
+	sort.SliceStable(r.sortedPrefixes, func(i, j int) bool {
+		return len(r.sortedPrefixes[i]) > len(r.sortedPrefixes[j])
+	})
+	return nil
+}
+
+// DefineAlias registers an alternative spelling for an already defined unit.
+// The alias shares the target's scale and prefix options.
+//
+// Example:
+//
+//	reg.DefineAlias("mho", "S")
+func (r *Registry) DefineAlias(alias, target string) error {
+	if alias == "" {
+		return fmt.Errorf("cannot define alias with empty symbol")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.definedLocked(alias) {
+		return fmt.Errorf("unit %s is already defined", alias)
+	}
+	canonical, ok := r.canonicalLocked(target)
+	if !ok {
+		return fmt.Errorf("cannot alias %s to undefined unit %s", alias, target)
+	}
+	r.aliases[alias] = canonical
+	return nil
+}
+
+// definedLocked reports whether symbol is a unit or alias. Callers must hold r.mu.
+func (r *Registry) definedLocked(symbol string) bool {
+	if _, ok := r.units[symbol]; ok {
+		return true
+	}
+	_, ok := r.aliases[symbol]
+	return ok
+}
+
+// canonicalLocked follows an alias to the unit symbol it names. Callers must hold r.mu.
+func (r *Registry) canonicalLocked(symbol string) (string, bool) {
+	if _, ok := r.units[symbol]; ok {
+		return symbol, true
+	}
+	target, ok := r.aliases[symbol]
+	return target, ok
+}
+
+// lookupLocked returns the definition of a unit symbol or alias. Callers must hold r.mu.
+func (r *Registry) lookupLocked(symbol string) (registeredUnit, bool) {
+	canonical, ok := r.canonicalLocked(symbol)
+	if !ok {
+		return registeredUnit{}, false
+	}
+	return r.units[canonical], true
+}
+
+// Resolve implements the Context interface.
+// Exact symbols and aliases win over prefixed interpretations, so "min" is a
+// minute rather than a milli-inch and "cd" is a candela rather than a centiday.
+func (r *Registry) Resolve(symbol string) (Unit, error) {
+	// Handle special case for dimensionless unit
+	if symbol == "1" || symbol == "" {
+		return One, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if def, ok := r.lookupLocked(symbol); ok {
+		return def.unit, nil
+	}
+
+	// Try with prefixes, longest first
+	for _, prefix := range r.sortedPrefixes {
+		if !strings.HasPrefix(symbol, prefix) {
+			continue
+		}
+
+		def, ok := r.lookupLocked(symbol[len(prefix):])
+		if !ok {
+			continue
+		}
+
+		p := r.prefixes[prefix]
+		if !def.opts.allows(prefix, p) {
+			continue
+		}
+
+		scaled := def.unit
+		scaled.Value *= p.factor
+		return scaled, nil
+	}
+
+	return Unit{}, &UnknownUnitError{Symbol: symbol, Pos: -1, Suggestions: r.suggestLocked(symbol)}
+}
+
+// affineSymbol finds the registered affine unit whose zero lies at offset.
+// It lets the formatter render absolute temperatures on the scale they were parsed from.
+func (r *Registry) affineSymbol(offset float64) (string, Unit, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found string
+	for symbol, def := range r.units {
+		if def.unit.Offset != offset {
+			continue
+		}
+		// Pick the lexically smallest match so the output is deterministic
+		if found == "" || symbol < found {
+			found = symbol
+		}
+	}
+	if found == "" {
+		return "", Unit{}, false
+	}
+	return found, r.units[found].unit, true
+}
+
+// Symbols returns every unit symbol and alias known to the registry, sorted.
+func (r *Registry) Symbols() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	symbols := make([]string, 0, len(r.units)+len(r.aliases))
+	for s := range r.units {
+		symbols = append(symbols, s)
+	}
+	for s := range r.aliases {
+		symbols = append(symbols, s)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// ParseUnit parses a unit string like "km/h" using this registry.
+// See the package-level ParseUnit for the accepted syntax.
+// The registry's own units and aliases take precedence over SymbolicUnits, so a
+// registry may define psi or dBm differently.
+func (r *Registry) ParseUnit(input string) (Unit, error) {
+	// Handle special cases
+	if input == "" || input == "1" {
+		return One, nil
+	}
+
+	r.mu.RLock()
+	def, defined := r.lookupLocked(input)
+	r.mu.RUnlock()
+	if defined {
+		return def.unit, nil
+	}
+	if unit, ok := SymbolicUnits[input]; ok {
+		return unit, nil
+	}
+
+	// Use AST-based parser for complex expressions
+	return parseUnitExprWithAST(input, r)
+}
+
+// Parse parses a full expression like "100 km/h" using this registry.
+// See the package-level Parse for the accepted syntax.
+func (r *Registry) Parse(input string) (Unit, error) {
+	if u, ok, err := r.parseNonFinite(input); ok {
+		return u, err
+	}
+
+	tokens, err := tokenizeFully(input)
+	if err != nil {
+		return One, fmt.Errorf("invalid unit expression: %w", err)
+	}
+
+	// The leading number is the value; everything after it is the unit
+	if tokens[0].Kind != Number {
+		return One, &SyntaxError{Input: input, Offset: tokens[0].Pos.Offset, Msg: "invalid numeric value"}
+	}
+	val, err := strconv.ParseFloat(tokens[0].Value, 64)
+	if err != nil {
+		return One, fmt.Errorf("invalid numeric value: %w", err)
+	}
+
+	// Handle case with only a number (dimensionless unit)
+	if tokens[1].Kind == EOF {
+		return Scalar(val), nil
+	}
+
+	ast, err := newTokenParser(input, tokens[1:]).Parse()
+	if err != nil {
+		return One, err
+	}
+	unit, err := EvalAST(ast, r)
+	if err != nil {
+		return One, withInput(err, input)
+	}
+
+	return applyValue(unit, val), nil
+}
+
+// parseNonFinite handles "NaN m" and "+Inf W", which strconv.ParseFloat and the
+// formatter both produce but the tokenizer does not treat as numbers.
+// The boolean result reports whether the input had such a value.
+func (r *Registry) parseNonFinite(input string) (Unit, bool, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return One, false, nil
+	}
+	val, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || !(math.IsNaN(val) || math.IsInf(val, 0)) {
+		return One, false, nil
+	}
+
+	unit, err := r.ParseUnit(strings.Join(fields[1:], " "))
+	if err != nil {
+		return One, true, err
+	}
+	return applyValue(unit, val), true, nil
+}
+
+// applyValue scales a parsed unit by the number written in front of it
+func applyValue(unit Unit, val float64) Unit {
+	if unit.IsAbsolute() {
+		// A bare affine unit denotes an absolute point measured from its zero
+		unit.Value = val*unit.step() + unit.Offset
+		return unit
+	}
+
+	unit.Value *= val
+	return unit
+}
+
+// New creates a unit with a given value and unit symbol using this registry.
+// See the package-level New for details.
+func (r *Registry) New(value float64, symbol string) Unit {
+	// Special case for grams
+	if symbol == "g" {
+		return Unit{Value: value / 1000, Dimension: Mass}
+	}
+
+	// First try to parse as a direct unit
+	u, err := r.ParseUnit(symbol)
+	if err != nil {
+		// Return dimensionless unit as fallback
+		return Scalar(value)
+	}
+
+	if u.IsAbsolute() {
+		// Affine symbols have no meaningful "raw" value, so place value on their scale
+		return onScale(u, value)
+	}
+
+	u.Value = value
+	return u
+}
+
+// DecodeJSON parses a JSON string such as "101.325 kPa", or any other encoding
+// of JSONMode, into u using this registry.
+// It is the per-call counterpart of Unit.UnmarshalJSON, which always uses the default registry.
+func (r *Registry) DecodeJSON(data []byte, u *Unit) error {
+	return decodeJSON(r, data, u)
+}
+
+// In returns the value of u expressed in the target unit expression, which is
+// parsed with this registry. It is the counterpart of Unit.In for units from a
+// registry whose symbols differ from the default one, such as one created with
+// ContextOptions.AngleDimension, where rad has a dimension of its own.
+//
+// Example:
+//
+//	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+//	turn, _ := ctx.Parse("1 rev")
+//	rad, _ := ctx.In(turn, "rad") // 6.283185307179586
+func (r *Registry) In(u Unit, target string) (float64, error) {
+	t, err := r.ParseUnit(target)
+	if err != nil {
+		return 0, err
+	}
+
+	converted, err := u.ConvertTo(t)
+	if err != nil {
+		return 0, err
+	}
+	return converted.Value, nil
+}
+
+// FormatIn formats u in the target unit expression, parsed with this registry,
+// with the given number of digits after the decimal point. See the package-level
+// FormatIn for details.
+func (r *Registry) FormatIn(u Unit, target string, precision int) (string, error) {
+	value, err := r.In(u, target)
+	if err != nil {
+		return "", err
+	}
+
+	if precision < 0 {
+		return strconv.FormatFloat(value, 'g', 12, 64) + " " + target, nil
+	}
+	return strconv.FormatFloat(value, 'f', precision, 64) + " " + target, nil
+}
+
+// FormatAs formats u in the target unit expression, parsed with this registry,
+// keeping the target as written. It is the counterpart of Unit.FormatAs.
+func (r *Registry) FormatAs(u Unit, target string) (string, error) {
+	return r.FormatIn(u, target, -1)
+}
+
+// defaultRegistry is the registry used by the package-level parsing functions.
+var defaultRegistry atomic.Pointer[Registry]
+
+func init() {
+	defaultRegistry.Store(NewStandardContext())
+}
+
+// DefaultRegistry returns the registry used by Parse, ParseUnit, New and Unit.UnmarshalJSON.
+// Units defined on it become visible to every caller of those functions.
+//
+// Example:
+//
+//	si.DefaultRegistry().DefineUnit("atm", si.Pascals(101325), si.UnitOptions{})
+//	p := si.MustParse("1.2 atm")
+func DefaultRegistry() *Registry {
+	return defaultRegistry.Load()
+}
+
+// SetDefaultRegistry replaces the registry used by the package-level parsing functions.
+// Passing nil restores a fresh standard registry. It is safe to call while other
+// goroutines parse.
+func SetDefaultRegistry(r *Registry) {
+	if r == nil {
+		r = NewStandardContext()
+	}
+	defaultRegistry.Store(r)
+}
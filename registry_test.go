@@ -0,0 +1,243 @@
+package si_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// TestRegistryDefineUnit verifies a custom unit resolves with and without prefixes
+func TestRegistryDefineUnit(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("Torr", si.Pascals(101325.0/760), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+
+	got, err := reg.Parse("760 mTorr")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !si.IsDimension(got, si.Pascal.Dimension) {
+		t.Errorf("Dimension = %v, want %v", got.Dimension, si.Pascal.Dimension)
+	}
+	if math.Abs(got.Value-101.325) > 1e-9 {
+		t.Errorf("Value = %v, want 101.325", got.Value)
+	}
+}
+
+// TestRegistryDefineUnitDuplicate verifies redefining a symbol is rejected
+func TestRegistryDefineUnitDuplicate(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("m", si.Meter, si.UnitOptions{}); err == nil {
+		t.Error("Expected error when redefining m")
+	}
+}
+
+// TestRegistryDefineUnitZeroScale verifies a zero scale is rejected
+func TestRegistryDefineUnitZeroScale(t *testing.T) {
+	reg := si.NewRegistry()
+	if err := reg.DefineUnit("zero", si.Scalar(0), si.UnitOptions{}); err == nil {
+		t.Error("Expected error for zero scale")
+	}
+}
+
+// TestRegistryPrefixNone verifies that PrefixNone units only resolve verbatim
+func TestRegistryPrefixNone(t *testing.T) {
+	reg := si.NewStandardContext()
+	if _, err := reg.ParseUnit("kh"); err == nil {
+		t.Error("Expected error for prefixed hour")
+	}
+	if _, err := reg.ParseUnit("kkg"); err == nil {
+		t.Error("Expected error for prefixed kilogram")
+	}
+}
+
+// TestRegistryPrefixOnly verifies the Only allow-list restricts prefixes
+func TestRegistryPrefixOnly(t *testing.T) {
+	// A bare registry, since the standard one already defines the tonne
+	reg := si.NewRegistry()
+	for symbol, factor := range map[string]float64{"k": 1e3, "M": 1e6, "G": 1e9} {
+		if err := reg.DefinePrefix(symbol, factor); err != nil {
+			t.Fatalf("DefinePrefix error: %v", err)
+		}
+	}
+	tonne := si.Kilograms(1000)
+	if err := reg.DefineUnit("t", tonne, si.UnitOptions{Only: []string{"k", "M"}}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+
+	kt, err := reg.ParseUnit("kt")
+	if err != nil {
+		t.Fatalf("ParseUnit(kt) error: %v", err)
+	}
+	if math.Abs(kt.Value-1e6) > 1e-6 {
+		t.Errorf("kt value = %v, want 1e6", kt.Value)
+	}
+
+	if _, err := reg.ParseUnit("Gt"); err == nil {
+		t.Error("Expected error for Gt outside the allow-list")
+	}
+}
+
+// TestRegistryBinaryPrefix verifies binary prefixes only attach to units that allow them
+func TestRegistryBinaryPrefix(t *testing.T) {
+	reg := si.NewStandardContext()
+
+	kib, err := reg.ParseUnit("KiB")
+	if err != nil {
+		t.Fatalf("ParseUnit(KiB) error: %v", err)
+	}
+	if kib.Value != 1024 {
+		t.Errorf("KiB value = %v, want 1024", kib.Value)
+	}
+
+	if _, err := reg.ParseUnit("Kim"); err == nil {
+		t.Error("Expected error for binary prefix on meter")
+	}
+}
+
+// TestRegistryDefinePrefix verifies a custom prefix attaches to existing units
+func TestRegistryDefinePrefix(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefinePrefix("X", 1e27); err != nil {
+		t.Fatalf("DefinePrefix error: %v", err)
+	}
+
+	got, err := reg.ParseUnit("Xg")
+	if err != nil {
+		t.Fatalf("ParseUnit(Xg) error: %v", err)
+	}
+	if math.Abs(got.Value/1e24-1) > 1e-12 {
+		t.Errorf("Xg value = %v, want 1e24", got.Value)
+	}
+}
+
+// TestRegistryDefineAlias verifies aliases share the target definition and prefixes
+func TestRegistryDefineAlias(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("Torr", si.Pascals(101325.0/760), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+	if err := reg.DefineAlias("torr", "Torr"); err != nil {
+		t.Fatalf("DefineAlias error: %v", err)
+	}
+
+	got, err := reg.ParseUnit("mtorr")
+	if err != nil {
+		t.Fatalf("ParseUnit(mtorr) error: %v", err)
+	}
+	if math.Abs(got.Value-101.325/760) > 1e-12 {
+		t.Errorf("mtorr value = %v, want %v", got.Value, 101.325/760)
+	}
+}
+
+// TestRegistryDefineAliasUndefined verifies aliasing an unknown unit fails
+func TestRegistryDefineAliasUndefined(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineAlias("foo", "furlong"); err == nil {
+		t.Error("Expected error aliasing undefined unit")
+	}
+}
+
+// TestRegistryIsolation verifies a custom registry does not leak into the default one
+func TestRegistryIsolation(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("furlong", si.Meters(201.168), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+
+	if _, err := si.ParseUnit("furlong"); err == nil {
+		t.Error("Default registry should not know furlong")
+	}
+}
+
+// TestRegistryOverridesSymbolicUnits verifies a registry's own definitions of
+// psi and dBm win over SymbolicUnits
+func TestRegistryOverridesSymbolicUnits(t *testing.T) {
+	reg := si.NewRegistry()
+	if err := reg.DefineUnit("psi", si.Pascals(1), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+	if err := reg.DefineUnit("decibel_mw", si.Watts(2), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+	if err := reg.DefineAlias("dBm", "decibel_mw"); err != nil {
+		t.Fatalf("DefineAlias error: %v", err)
+	}
+
+	tests := map[string]si.Unit{"psi": si.Pascals(1), "dBm": si.Watts(2)}
+	for symbol, want := range tests {
+		got, err := reg.ParseUnit(symbol)
+		if err != nil {
+			t.Fatalf("ParseUnit(%q) error: %v", symbol, err)
+		}
+		if !got.Equals(want) {
+			t.Errorf("ParseUnit(%q) = %v, want %v", symbol, got.Value, want.Value)
+		}
+	}
+
+	// Registries that do not define them still fall back to SymbolicUnits
+	if got, err := si.NewRegistry().ParseUnit("dBm"); err != nil || got.Value != 1e-3 {
+		t.Errorf("ParseUnit(dBm) = %v, %v, want 0.001", got.Value, err)
+	}
+}
+
+// TestSetDefaultRegistry verifies the package-level functions follow the default registry
+func TestSetDefaultRegistry(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("atm", si.Pascals(101325), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+
+	si.SetDefaultRegistry(reg)
+	defer si.SetDefaultRegistry(nil)
+
+	got, err := si.Parse("2 atm")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if math.Abs(got.Value-202650) > 1e-6 {
+		t.Errorf("Value = %v, want 202650", got.Value)
+	}
+
+	var u si.Unit
+	if err := json.Unmarshal([]byte(`"1 atm"`), &u); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if math.Abs(u.Value-101325) > 1e-6 {
+		t.Errorf("Unmarshalled value = %v, want 101325", u.Value)
+	}
+
+	if got := si.New(3, "atm"); !si.IsDimension(got, si.Pascal.Dimension) {
+		t.Errorf("New dimension = %v, want %v", got.Dimension, si.Pascal.Dimension)
+	}
+}
+
+// TestRegistryDecodeJSON verifies per-call JSON decoding with a custom registry
+func TestRegistryDecodeJSON(t *testing.T) {
+	reg := si.NewStandardContext()
+	if err := reg.DefineUnit("atm", si.Pascals(101325), si.UnitOptions{}); err != nil {
+		t.Fatalf("DefineUnit error: %v", err)
+	}
+
+	var u si.Unit
+	if err := reg.DecodeJSON([]byte(`"3 atm"`), &u); err != nil {
+		t.Fatalf("DecodeJSON error: %v", err)
+	}
+	if math.Abs(u.Value-303975) > 1e-6 {
+		t.Errorf("Value = %v, want 303975", u.Value)
+	}
+}
+
+// TestParseMilligram verifies prefixes attach to the gram rather than the kilogram
+func TestParseMilligram(t *testing.T) {
+	got, err := si.ParseUnit("mg")
+	if err != nil {
+		t.Fatalf("ParseUnit(mg) error: %v", err)
+	}
+	if math.Abs(got.Value-1e-6) > 1e-18 {
+		t.Errorf("mg value = %v, want 1e-6", got.Value)
+	}
+}
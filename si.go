@@ -1,10 +1,7 @@
 package si
 
 import (
-	"fmt"
 	"math"
-	"strconv"
-	"strings"
 )
 
 // SI base dimensions for common use.
@@ -13,54 +10,68 @@ import (
 // Temperature (kelvins), Substance (moles), and Luminosity (candelas).
 var (
 	// Length represents the dimension of length (meters).
-	Length = Dimension{1, 0, 0, 0, 0, 0, 0}
+	Length = NewDimension(1, 0, 0, 0, 0, 0, 0)
 
 	// Mass represents the dimension of mass (kilograms).
 	// Note: kilogram is the SI base unit for mass, unlike other base units which don't have prefixes
-	Mass = Dimension{0, 1, 0, 0, 0, 0, 0}
+	Mass = NewDimension(0, 1, 0, 0, 0, 0, 0)
 
 	// TimeDim represents the dimension of time (seconds).
 	// Named TimeDim to avoid conflict with the Time type.
-	TimeDim = Dimension{0, 0, 1, 0, 0, 0, 0}
+	TimeDim = NewDimension(0, 0, 1, 0, 0, 0, 0)
 
 	// Current represents the dimension of electric current (amperes).
-	Current = Dimension{0, 0, 0, 1, 0, 0, 0}
+	Current = NewDimension(0, 0, 0, 1, 0, 0, 0)
 
 	// Temperature represents the dimension of temperature (kelvins).
-	Temperature = Dimension{0, 0, 0, 0, 1, 0, 0}
+	Temperature = NewDimension(0, 0, 0, 0, 1, 0, 0)
 
 	// Substance represents the dimension of amount of substance (moles).
-	Substance = Dimension{0, 0, 0, 0, 0, 1, 0}
+	Substance = NewDimension(0, 0, 0, 0, 0, 1, 0)
 
 	// Luminosity represents the dimension of luminous intensity (candelas).
-	Luminosity = Dimension{0, 0, 0, 0, 0, 0, 1}
+	Luminosity = NewDimension(0, 0, 0, 0, 0, 0, 1)
+
+	// Information represents the dimension of information (bytes).
+	// The SI counts information as dimensionless; it is tracked here so that
+	// sizes and data rates are not mistaken for plain numbers and frequencies.
+	Information = NewDimension(0, 0, 0, 0, 0, 0, 0, 1)
+
+	// Angle represents the dimension of plane angle (radians) in registries created
+	// with ContextOptions.AngleDimension. The SI treats angles as dimensionless,
+	// which is what the standard registry does.
+	Angle = NewDimension(0, 0, 0, 0, 0, 0, 0, 0, 1)
 
 	// Dimensionless represents quantities without physical dimensions.
-	Dimensionless = Dimension{0, 0, 0, 0, 0, 0, 0}
+	Dimensionless = Dimension{}
 )
 
 // Prefixes defines both SI and binary prefixes for unit scaling.
-// SI prefixes range from yocto (y, 10^-24) to yotta (Y, 10^24).
-// Binary prefixes (Ki, Mi, Gi, Ti, Pi, Ei) are also included.
+// SI prefixes range from quecto (q, 10^-30) to quetta (Q, 10^30).
+// Binary prefixes (Ki, Mi, Gi, Ti, Pi, Ei, Zi, Yi) are also included.
 var Prefixes = map[string]float64{
-	"Y": 1e24, "Z": 1e21, "E": 1e18, "P": 1e15,
+	"Q": 1e30, "R": 1e27, "Y": 1e24, "Z": 1e21, "E": 1e18, "P": 1e15,
 	"T": 1e12, "G": 1e9, "M": 1e6, "k": 1e3,
 	"h": 1e2, "da": 1e1, "": 1, "d": 1e-1,
 	"c": 1e-2, "m": 1e-3, "u": 1e-6, "μ": 1e-6, "n": 1e-9,
 	"p": 1e-12, "f": 1e-15, "a": 1e-18, "z": 1e-21,
-	"y": 1e-24, "Ki": math.Pow(2, 10), "Mi": math.Pow(2, 20),
+	"y": 1e-24, "r": 1e-27, "q": 1e-30, "Ki": math.Pow(2, 10), "Mi": math.Pow(2, 20),
 	"Gi": math.Pow(2, 30), "Ti": math.Pow(2, 40), "Pi": math.Pow(2, 50), "Ei": math.Pow(2, 60),
+	"Zi": math.Pow(2, 70), "Yi": math.Pow(2, 80),
 }
 
 // SymbolicUnits maps domain-specific unit symbols to their dimensions.
 // This allows support for non-standard units like dBm.
+// ParseUnit only consults it for symbols the registry does not define itself.
 var SymbolicUnits = map[string]Unit{
-	"dBm": {1e-3, Dimension{2, 1, -3, 0, 0, 0, 0}},
-	"psi": {6894.76, Pascal.Dimension}, // 1 psi = 6894.76 Pa
+	"dBm": {Value: 1e-3, Dimension: NewDimension(2, 1, -3, 0, 0, 0, 0)},
+	"psi": {Value: psiPascals, Dimension: Pascal.Dimension}, // 1 psi = 1 lbf/in² = 6894.757293168361 Pa
 }
 
 // ParseUnit parses a unit string like "km/h" into a Unit.
 // It handles basic units, prefixed units, compound units, and symbolic units.
+// Symbols are resolved with the default registry; use Registry.ParseUnit to
+// resolve against a different one.
 //
 // Examples:
 //
@@ -69,17 +80,7 @@ var SymbolicUnits = map[string]Unit{
 //	energy, _ := ParseUnit("kW*h")
 //	pressure, _ := ParseUnit("kg/(m*s^2)")
 func ParseUnit(input string) (Unit, error) {
-	// Handle special cases
-	if input == "" || input == "1" {
-		return One, nil
-	}
-
-	if unit, ok := SymbolicUnits[input]; ok {
-		return unit, nil
-	}
-
-	// Use AST-based parser for complex expressions
-	return parseUnitExprWithAST(input)
+	return DefaultRegistry().ParseUnit(input)
 }
 
 // Register conversion functions between si.Unit and parser.Unit
@@ -89,20 +90,12 @@ func init() {
 	RegisterConversionFunctions(
 		// Convert parser.Unit to si.Unit
 		func(u Unit) interface{} {
-			var dim Dimension
-			for i := range dim {
-				dim[i] = u.Dimension[i]
-			}
-			return Unit{Value: u.Value, Dimension: dim}
+			return Unit{Value: u.Value, Dimension: u.Dimension, Offset: u.Offset, Kind: u.Kind}
 		},
 		// Convert si.Unit to parser.Unit
 		func(i interface{}) Unit {
 			if u, ok := i.(Unit); ok {
-				var dim Dimension
-				for i := range dim {
-					dim[i] = u.Dimension[i]
-				}
-				return Unit{Value: u.Value, Dimension: dim}
+				return Unit{Value: u.Value, Dimension: u.Dimension, Offset: u.Offset, Kind: u.Kind}
 			}
 			return Unit{}
 		},
@@ -111,10 +104,7 @@ func init() {
 
 // parseUnitExprWithAST parses just the unit part (no value) using the AST-based parser
 // This internal function handles the complex logic of parsing unit expressions.
-func parseUnitExprWithAST(input string) (Unit, error) {
-	// Create standard context with SI units
-	ctx := NewStandardContext()
-
+func parseUnitExprWithAST(input string, ctx Context) (Unit, error) {
 	// Parse the unit expression
 	parserUnit, err := ParseComplexUnit(input, ctx)
 	if err != nil {
@@ -128,6 +118,11 @@ func parseUnitExprWithAST(input string) (Unit, error) {
 
 // Parse splits and parses a full unit expression like "100 km/h".
 // This extracts the numeric value and unit component from a string.
+// The value may be signed and written in scientific notation ("-1.2e-3",
+// "1.5×10^3", "4.7×10⁻⁶"), and may be glued to its unit ("100km/h").
+// Exponents in the unit may use a caret or superscripts ("m^-2", "s⁻¹").
+// Symbols are resolved with the default registry; use Registry.Parse to
+// resolve against a different one.
 //
 // Examples:
 //
@@ -135,35 +130,9 @@ func parseUnitExprWithAST(input string) (Unit, error) {
 //	mass, _ := Parse("500 g")         // 0.5 kg
 //	pressure, _ := Parse("101.325 kPa") // 101325 Pa
 //	temp, _ := Parse("25 °C")         // 298.15 K
+//	area, _ := Parse("1.5×10³ m²")    // 1500 m^2
 func Parse(input string) (Unit, error) {
-	fields := strings.Fields(input)
-
-	// Handle case with only a number (dimensionless unit)
-	if len(fields) == 1 {
-		val, err := strconv.ParseFloat(fields[0], 64)
-		if err != nil {
-			return One, fmt.Errorf("invalid numeric value: %w", err)
-		}
-		return Scalar(val), nil
-	}
-
-	if len(fields) < 2 {
-		return One, fmt.Errorf("invalid unit expression: %s", input)
-	}
-
-	val, err := strconv.ParseFloat(fields[0], 64)
-	if err != nil {
-		return One, fmt.Errorf("invalid numeric value: %w", err)
-	}
-
-	// Use AST-based parser for unit component
-	unit, err := parseUnitExprWithAST(strings.Join(fields[1:], ""))
-	if err != nil {
-		return One, err
-	}
-
-	unit.Value *= val
-	return unit, nil
+	return DefaultRegistry().Parse(input)
 }
 
 // MustParse works like Parse but panics on error.
@@ -183,30 +152,19 @@ func MustParse(input string) Unit {
 
 // New creates a unit with a given value and unit string (e.g. "kg").
 // This is a low-level function used by the helper functions but can be called directly.
+// Symbols are resolved with the default registry.
 //
 // Example:
 //
 //	mass := New(1.5, "kg")   // 1.5 kg
 //	length := New(100, "cm") // 1 m
 func New(value float64, symbol string) Unit {
-	// Special case for grams
-	if symbol == "g" {
-		return Unit{value / 1000, Mass}
-	}
-
-	// First try to parse as a direct unit
-	u, err := ParseUnit(symbol)
-	if err != nil {
-		// Return dimensionless unit as fallback
-		return Scalar(value)
-	}
-
-	u.Value = value
-	return u
+	return DefaultRegistry().New(value, symbol)
 }
 
 // String returns a human-readable representation of the unit using SI standards.
-// The output includes the value with appropriate scaling prefix and unit symbol.
+// The output includes the value with appropriate scaling prefix and unit symbol,
+// or the unit the default DisplayProfile prefers for the dimension.
 //
 // Examples:
 //
@@ -216,60 +174,87 @@ func New(value float64, symbol string) Unit {
 //	energy := Joule.Mul(Scalar(5000))
 //	fmt.Println(energy) // "5 kJ"
 func (u Unit) String() string {
-	return FormatUnitWithPrefix(u)
+	return FormatWithProfile(u, DefaultDisplayProfile())
 }
 
 // Named constants for SI base units.
 // These represent the basic SI units with their correct dimensions.
 var (
 	// Meter is the SI base unit of length (1 m).
-	Meter = Unit{1, Length}
+	Meter = Unit{Value: 1, Dimension: Length}
 
 	// Kilogram is the SI base unit of mass (1 kg).
-	Kilogram = Unit{1, Mass}
+	Kilogram = Unit{Value: 1, Dimension: Mass}
 
 	// Second is the SI base unit of time (1 s).
-	Second = Unit{1, TimeDim}
+	Second = Unit{Value: 1, Dimension: TimeDim}
 
 	// Ampere is the SI base unit of electric current (1 A).
-	Ampere = Unit{1, Current}
+	Ampere = Unit{Value: 1, Dimension: Current}
 
 	// Kelvin is the SI base unit of temperature (1 K).
-	Kelvin = Unit{1, Temperature}
+	Kelvin = Unit{Value: 1, Dimension: Temperature}
 
 	// Mole is the SI base unit of amount of substance (1 mol).
-	Mole = Unit{1, Substance}
+	Mole = Unit{Value: 1, Dimension: Substance}
 
 	// Candela is the SI base unit of luminous intensity (1 cd).
-	Candela = Unit{1, Luminosity}
+	Candela = Unit{Value: 1, Dimension: Luminosity}
 
 	// One represents a dimensionless quantity with value 1.
-	One = Unit{1, Dimensionless}
+	One = Unit{Value: 1, Dimension: Dimensionless}
 )
 
 // Named derived units for convenience.
-// These are common derived units defined in terms of the base units.
+// These are common derived units defined in terms of the base units. Those
+// sharing a dimension with another quantity carry the QuantityKind of the
+// symbol they are parsed from, so Joule cannot be added to a torque.
 var (
 	// Newton is the SI unit of force (1 N = 1 kg·m/s²).
 	Newton = Kilogram.Mul(Meter).Div(Second.Pow(2))
 
-	// Joule is the SI unit of energy (1 J = 1 N·m).
-	Joule = Newton.Mul(Meter)
+	// Joule is the SI unit of energy (1 J = 1 N·m), of kind KindEnergy.
+	Joule = ofKind(Newton.Mul(Meter), KindEnergy)
 
 	// Watt is the SI unit of power (1 W = 1 J/s).
 	Watt = Joule.Div(Second)
 
-	// Pascal is the SI unit of pressure (1 Pa = 1 N/m²).
-	Pascal = Newton.Div(Meter.Pow(2))
+	// Pascal is the SI unit of pressure (1 Pa = 1 N/m²), of kind KindPressure.
+	Pascal = ofKind(Newton.Div(Meter.Pow(2)), KindPressure)
 
-	// Hertz is the SI unit of frequency (1 Hz = 1/s).
-	Hertz = Unit{1, Dimension{0, 0, -1, 0, 0, 0, 0}}
+	// Hertz is the SI unit of frequency (1 Hz = 1/s), of kind KindFrequency.
+	Hertz = Unit{Value: 1, Dimension: NewDimension(0, 0, -1, 0, 0, 0, 0), Kind: KindFrequency}
 
 	// Coulomb is the SI unit of electric charge (1 C = 1 A·s).
 	Coulomb = Ampere.Mul(Second)
 
 	// Volt is the SI unit of electric potential (1 V = 1 W/A).
 	Volt = Watt.Div(Ampere)
+
+	// Ohm is the SI unit of electric resistance (1 Ω = 1 V/A).
+	Ohm = Volt.Div(Ampere)
+
+	// Siemens is the SI unit of electric conductance (1 S = 1 A/V).
+	Siemens = Ampere.Div(Volt)
+
+	// Farad is the SI unit of capacitance (1 F = 1 C/V).
+	Farad = Coulomb.Div(Volt)
+
+	// Weber is the SI unit of magnetic flux (1 Wb = 1 V·s).
+	Weber = Volt.Mul(Second)
+
+	// Henry is the SI unit of inductance (1 H = 1 Wb/A).
+	Henry = Weber.Div(Ampere)
+
+	// Tesla is the SI unit of magnetic flux density (1 T = 1 Wb/m²).
+	Tesla = Weber.Div(Meter.Pow(2))
+
+	// Gray is the SI unit of absorbed dose (1 Gy = 1 J/kg), of kind KindAbsorbedDose.
+	// It shares its dimension with the sievert and with specific energy.
+	Gray = ofKind(Joule.Div(Kilogram), KindAbsorbedDose)
+
+	// Katal is the SI unit of catalytic activity (1 kat = 1 mol/s), of kind KindCatalyticActivity.
+	Katal = ofKind(Mole.Div(Second), KindCatalyticActivity)
 )
 
 // Convenience helpers for common physical quantities.
@@ -335,6 +320,27 @@ func Centimeters(n float64) Unit { return New(n/100, "m") }
 //	length := Millimeters(150)  // 150 millimeters = 0.15 meters
 func Millimeters(n float64) Unit { return New(n/1000, "m") }
 
+// Inches creates a length unit in inches converted to meters.
+//
+// Example:
+//
+//	radius := Inches(1)  // 0.0254 m
+func Inches(n float64) Unit { return New(n*inchMeters, "m") }
+
+// Feet creates a length unit in feet converted to meters.
+//
+// Example:
+//
+//	altitude := Feet(35000)  // 10668 m
+func Feet(n float64) Unit { return New(n*footMeters, "m") }
+
+// Miles creates a length unit in international miles converted to meters.
+//
+// Example:
+//
+//	distance := Miles(26.2)  // 42164.8128 m
+func Miles(n float64) Unit { return New(n*mileMeters, "m") }
+
 // Mass units
 
 // Grams creates a mass unit in grams converted to kilograms.
@@ -351,21 +357,44 @@ func Grams(n float64) Unit { return New(n/1000, "kg") }
 //	mass := Kilograms(75)  // 75 kilograms
 func Kilograms(n float64) Unit { return New(n, "kg") }
 
+// Tonnes creates a mass unit in metric tonnes converted to kilograms.
+//
+// Example:
+//
+//	load := Tonnes(2.5)  // 2500 kilograms
+func Tonnes(n float64) Unit { return New(n*1000, "kg") }
+
+// Pounds creates a mass unit in avoirdupois pounds converted to kilograms.
+//
+// Example:
+//
+//	load := Pounds(10)  // 4.5359237 kg
+func Pounds(n float64) Unit { return New(n*poundKilogram, "kg") }
+
 // Temperature units
 
-// Celsius creates a temperature unit in degrees Celsius converted to kelvins.
+// degreeCelsius and degreeFahrenheit are one step on the absolute temperature
+// scales registered as °C and °F
+var (
+	degreeCelsius    = Affine(Kelvin, 273.15)
+	degreeFahrenheit = Affine(Kelvin.Mul(Scalar(5.0/9.0)), 459.67*5.0/9.0)
+)
+
+// Celsius creates an absolute temperature in degrees Celsius, like parsing "25 °C".
+// Its Value is in kelvins, and it cannot be added to another absolute temperature.
 //
 // Example:
 //
-//	temperature := Celsius(25)  // 25°C = 298.15 K
-func Celsius(n float64) Unit { return New(n+273.15, "K") }
+//	temperature := Celsius(25)  // 25 °C, Value 298.15
+func Celsius(n float64) Unit { return onScale(degreeCelsius, n) }
 
-// Fahrenheit creates a temperature unit in degrees Fahrenheit converted to kelvins.
+// Fahrenheit creates an absolute temperature in degrees Fahrenheit, like parsing "77 °F".
+// Its Value is in kelvins, and it cannot be added to another absolute temperature.
 //
 // Example:
 //
-//	temperature := Fahrenheit(77)  // 77°F = 298.15 K
-func Fahrenheit(n float64) Unit { return New((n-32)*5/9+273.15, "K") }
+//	temperature := Fahrenheit(77)  // 77 °F, Value 298.15
+func Fahrenheit(n float64) Unit { return onScale(degreeFahrenheit, n) }
 
 // Kelvins creates a temperature unit in kelvins.
 //
@@ -385,7 +414,7 @@ func Kelvins(n float64) Unit { return New(n, "K") }
 //	celsius, _ := ToCelsius(temp)  // celsius = 26.85
 func ToCelsius(u Unit) (float64, error) {
 	if !IsDimension(u, Temperature) {
-		return 0, fmt.Errorf("not a temperature unit")
+		return 0, &DimensionMismatchError{Op: "convert", Left: u.Dimension, Right: Temperature}
 	}
 	return u.Value - 273.15, nil
 }
@@ -399,61 +428,99 @@ func ToCelsius(u Unit) (float64, error) {
 //	fahrenheit, _ := ToFahrenheit(temp)  // fahrenheit = 77
 func ToFahrenheit(u Unit) (float64, error) {
 	if !IsDimension(u, Temperature) {
-		return 0, fmt.Errorf("not a temperature unit")
+		return 0, &DimensionMismatchError{Op: "convert", Left: u.Dimension, Right: Temperature}
 	}
 	return (u.Value-273.15)*9/5 + 32, nil
 }
 
 // Data storage units
 
-// Megabytes creates a data unit in megabytes.
+// Bytes creates a data unit in bytes.
+//
+// Example:
+//
+//	payload := Bytes(512)  // 512 B
+func Bytes(n float64) Unit { return New(n, "B") }
+
+// Bits creates a data unit in bits converted to bytes.
+//
+// Example:
+//
+//	word := Bits(32)  // 4 B
+func Bits(n float64) Unit { return New(n/8, "B") }
+
+// Megabytes creates a data unit in megabytes converted to bytes.
 //
 // Example:
 //
 //	size := Megabytes(15)  // 15 MB
-func Megabytes(n float64) Unit { return New(n, "MB") }
+func Megabytes(n float64) Unit { return New(n*1e6, "B") }
 
-// Gigabytes creates a data unit in gigabytes.
+// Gigabytes creates a data unit in gigabytes converted to bytes.
 //
 // Example:
 //
 //	size := Gigabytes(1.5)  // 1.5 GB
-func Gigabytes(n float64) Unit { return New(n, "GB") }
+func Gigabytes(n float64) Unit { return New(n*1e9, "B") }
 
-// Terabytes creates a data unit in terabytes.
+// Terabytes creates a data unit in terabytes converted to bytes.
 //
 // Example:
 //
 //	size := Terabytes(2)  // 2 TB
-func Terabytes(n float64) Unit { return New(n, "TB") }
+func Terabytes(n float64) Unit { return New(n*1e12, "B") }
 
 // Kibibytes creates a data unit in kibibytes (1024 bytes).
 //
 // Example:
 //
 //	size := Kibibytes(1024)  // 1024 KiB = 1 MiB
-func Kibibytes(n float64) Unit { return New(n, "KiB") }
+func Kibibytes(n float64) Unit { return New(n*(1<<10), "B") }
 
 // Mebibytes creates a data unit in mebibytes (1024 KiB).
 //
 // Example:
 //
 //	size := Mebibytes(2)  // 2 MiB
-func Mebibytes(n float64) Unit { return New(n, "MiB") }
+func Mebibytes(n float64) Unit { return New(n*(1<<20), "B") }
 
 // Gibibytes creates a data unit in gibibytes (1024 MiB).
 //
 // Example:
 //
 //	size := Gibibytes(1)  // 1 GiB
-func Gibibytes(n float64) Unit { return New(n, "GiB") }
+func Gibibytes(n float64) Unit { return New(n*(1<<30), "B") }
 
 // Tebibytes creates a data unit in tebibytes (1024 GiB).
 //
 // Example:
 //
 //	size := Tebibytes(0.5)  // 0.5 TiB
-func Tebibytes(n float64) Unit { return New(n, "TiB") }
+func Tebibytes(n float64) Unit { return New(n*(1<<40), "B") }
+
+// Angle units. They are dimensionless unless the default registry was created
+// with ContextOptions.AngleDimension.
+
+// Radians creates an angle in radians.
+//
+// Example:
+//
+//	turn := Radians(math.Pi)  // half a revolution
+func Radians(n float64) Unit { return New(n, "rad") }
+
+// Degrees creates an angle in degrees converted to radians.
+//
+// Example:
+//
+//	slope := Degrees(30)  // 0.5235987755982988 rad
+func Degrees(n float64) Unit { return New(n*math.Pi/180, "rad") }
+
+// RPM creates a rotational speed in revolutions per minute converted to radians per second.
+//
+// Example:
+//
+//	speed := RPM(1500)  // 157.07963267948966 rad/s
+func RPM(n float64) Unit { return New(n*2*math.Pi/60, "rad/s") }
 
 // Electrical and physical units
 
@@ -496,8 +563,8 @@ func Pascals(n float64) Unit { return New(n, "Pa") }
 //
 // Example:
 //
-//	pressure := Psi(14.7)  // 14.7 psi = 101356.5 Pa ≈ 1 atm
-func Psi(n float64) Unit { return New(n*6894.76, "Pa") }
+//	pressure := Psi(14.7)  // 14.7 psi = 101352.9322095749 Pa ≈ 1 atm
+func Psi(n float64) Unit { return New(n*psiPascals, "Pa") }
 
 // Joules creates an energy unit in joules.
 //
@@ -542,7 +609,7 @@ func IsDimension(u Unit, expected Dimension) bool {
 //	kPa, _ := ToKiloPascals(pressure)  // kPa = 101.325
 func ToKiloPascals(u Unit) (float64, error) {
 	if !IsDimension(u, Pascal.Dimension) {
-		return 0, fmt.Errorf("not a pressure unit")
+		return 0, &DimensionMismatchError{Op: "convert", Left: u.Dimension, Right: Pascal.Dimension}
 	}
 	return u.Value / 1000, nil
 }
@@ -106,6 +106,22 @@ func BenchmarkString(b *testing.B) {
 	}
 }
 
+// BenchmarkStringCompound benchmarks string conversion of compound units,
+// whose symbols are found by simplifying their dimension
+func BenchmarkStringCompound(b *testing.B) {
+	units := []si.Unit{
+		si.Meters(3).Div(si.Seconds(1)),
+		si.Watts(1).Div(si.Meter.Mul(si.Kelvin)),
+		si.Kilograms(2).Mul(si.Meter.Pow(2)).Div(si.Second.Pow(3).Mul(si.Ampere)),
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u := units[i%len(units)]
+		_ = u.String()
+	}
+}
+
 // BenchmarkComplexCalculation benchmarks a complex calculation with multiple operations
 func BenchmarkComplexCalculation(b *testing.B) {
 	// Thermal energy calculation: Q = m * c * ΔT
@@ -261,6 +277,47 @@ func BenchmarkMarshalJSON(b *testing.B) {
 	}
 }
 
+// BenchmarkMarshalBinary benchmarks binary marshalling
+func BenchmarkMarshalBinary(b *testing.B) {
+	units := []si.Unit{
+		si.Meters(100),
+		si.Watts(1500),
+		si.Celsius(25),
+		si.Newton.Mul(si.Meter),
+		si.Meter.Div(si.Second).Mul(si.Scalar(10)),
+	}
+
+	buf := make([]byte, 0, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u := units[i%len(units)]
+		var err error
+		buf, err = u.AppendBinary(buf[:0])
+		if err != nil {
+			b.Fatalf("Failed to marshal unit to binary: %v", err)
+		}
+	}
+}
+
+// BenchmarkAppendBlock benchmarks encoding a block of pressure samples
+func BenchmarkAppendBlock(b *testing.B) {
+	samples := make([]si.Unit, 1024)
+	for i := range samples {
+		samples[i] = si.Pascals(101325 + float64(i%50)*0.25)
+	}
+
+	buf := make([]byte, 0, 16*len(samples))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var err error
+		buf, err = si.AppendBlock(buf[:0], samples)
+		if err != nil {
+			b.Fatalf("Failed to encode block: %v", err)
+		}
+	}
+	b.ReportMetric(float64(len(buf))/float64(len(samples)), "B/sample")
+}
+
 // BenchmarkPressureConversion benchmarks pressure unit conversion
 func BenchmarkPressureConversion(b *testing.B) {
 	// 1 atm = 101325 Pa
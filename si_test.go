@@ -1,6 +1,7 @@
 package si_test
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -68,18 +69,18 @@ func TestParse(t *testing.T) {
 		want    si.Unit
 		wantErr bool
 	}{
-		{"simple value with unit", "10 m", si.Unit{10, si.Length}, false},
-		{"decimal value with unit", "3.14 kg", si.Unit{3.14, si.Mass}, false},
-		{"scientific notation with unit", "1.2e3 W", si.Unit{1200, si.Watt.Dimension}, false},
-		{"compound unit", "9.8 m/s^2", si.Unit{9.8, si.Meter.Div(si.Second.Pow(2)).Dimension}, false},
-		{"unit with prefix", "100 km/h", si.Unit{100000.0 / 3600.0, si.Meter.Div(si.Second).Dimension}, false},
+		{"simple value with unit", "10 m", si.Unit{Value: 10, Dimension: si.Length}, false},
+		{"decimal value with unit", "3.14 kg", si.Unit{Value: 3.14, Dimension: si.Mass}, false},
+		{"scientific notation with unit", "1.2e3 W", si.Unit{Value: 1200, Dimension: si.Watt.Dimension}, false},
+		{"compound unit", "9.8 m/s^2", si.Unit{Value: 9.8, Dimension: si.Meter.Div(si.Second.Pow(2)).Dimension}, false},
+		{"unit with prefix", "100 km/h", si.Unit{Value: 100000.0 / 3600.0, Dimension: si.Meter.Div(si.Second).Dimension}, false},
 		{"dimensionless without unit", "0.5", si.Scalar(0.5), false},
 		{"scientific notation without unit", "1.2e3", si.Scalar(1200), false},
 		{"invalid format", "invalid", si.Unit{}, true},
 		{"invalid value", "bad m", si.Unit{}, true},
-		{"energy in joules", "4.184 kJ", si.Unit{4184, si.Joule.Dimension}, false},
-		{"pressure", "101.325 kPa", si.Unit{101325, si.Pascal.Dimension}, false},
-		{"torque", "50 N*m", si.Unit{50, si.Newton.Mul(si.Meter).Dimension}, false},
+		{"energy in joules", "4.184 kJ", si.Unit{Value: 4184, Dimension: si.Joule.Dimension}, false},
+		{"pressure", "101.325 kPa", si.Unit{Value: 101325, Dimension: si.Pascal.Dimension}, false},
+		{"torque", "50 N*m", si.Unit{Value: 50, Dimension: si.Newton.Mul(si.Meter).Dimension}, false},
 	}
 
 	for _, tt := range tests {
@@ -262,6 +263,28 @@ func TestParsingSensorData(t *testing.T) {
 	}
 }
 
+// TestTemperatureHelpersAreAbsolute verifies Celsius and Fahrenheit match the
+// parsed scales, so adding two of them fails like adding two parsed temperatures
+func TestTemperatureHelpersAreAbsolute(t *testing.T) {
+	if got, want := si.Celsius(20), si.MustParse("20 °C"); got != want {
+		t.Errorf("Celsius(20) = %+v, want %+v", got, want)
+	}
+	if got, want := si.Fahrenheit(77), si.MustParse("77 °F"); got != want {
+		t.Errorf("Fahrenheit(77) = %+v, want %+v", got, want)
+	}
+	if got := si.Fahrenheit(77).String(); got != "77 °F" {
+		t.Errorf("Fahrenheit(77) = %q, want 77 °F", got)
+	}
+
+	var absolute *si.AbsoluteTemperatureError
+	if _, err := si.Celsius(20).Add(si.Celsius(30)); !errors.As(err, &absolute) {
+		t.Errorf("Celsius(20).Add(Celsius(30)) error = %v, want *si.AbsoluteTemperatureError", err)
+	}
+	if got, err := si.Celsius(30).Sub(si.Celsius(21)); err != nil || got.String() != "9 K" {
+		t.Errorf("Celsius(30).Sub(Celsius(21)) = %v, %v, want 9 K", got, err)
+	}
+}
+
 // TestUnitConversion tests converting between different compatible units
 func TestUnitConversion(t *testing.T) {
 	// Create temperature in Celsius and verify equivalent in Kelvin
@@ -500,9 +523,8 @@ func TestMotorTorqueCalculation(t *testing.T) {
 
 	power := si.Watts(5000) // 5 kW motor
 
-	// Convert rpm to rad/s: ω = rpm × (2π/60)
 	// 1500 rpm = 1500 * 2π/60 = 157.08 rad/s
-	angularVelocity := si.Scalar(1500 * 2 * math.Pi / 60).Div(si.Second)
+	angularVelocity := si.MustParse("1500 rpm")
 
 	// Calculate torque
 	torque := power.Div(angularVelocity)
@@ -660,14 +682,14 @@ func TestString(t *testing.T) {
 		// Compound units
 		{"velocity", si.Meter.Div(si.Second).Mul(si.Scalar(20)), "20 m/s"},
 		{"acceleration", si.Meter.Div(si.Second.Pow(2)).Mul(si.Scalar(9.81)), "9.81 m/s^2"},
-		{"energy_density", si.Joule.Div(si.Meter.Pow(3)).Mul(si.Scalar(5000)), "5 kPa"},
-		{"pressure_grad", si.Pascal.Div(si.Meter).Mul(si.Scalar(10)), "10 kg/(s^2*m^2)"},
+		{"energy_density", si.Joule.Div(si.Meter.Pow(3)).Mul(si.Scalar(5000)), "5 kJ/m^3"},
+		{"pressure_grad", si.Pascal.Div(si.Meter).Mul(si.Scalar(10)), "10 Pa/m"},
 
 		// Edge cases
 		{"zero", si.Scalar(0), "0"},
 		{"dimensionless", si.Scalar(0.75), "0.75"},
-		{"very_large", si.Meter.Mul(si.Scalar(1e15)), "1e+06 Gm"},
-		{"very_small", si.Second.Mul(si.Scalar(1e-15)), "0.001 ps"},
+		{"very_large", si.Meter.Mul(si.Scalar(1e15)), "1 Pm"},
+		{"very_small", si.Second.Mul(si.Scalar(1e-15)), "1 fs"},
 	}
 
 	for _, tt := range tests {
@@ -782,7 +804,7 @@ func TestPsiUnit(t *testing.T) {
 	}
 
 	// Calculate expected value in Pascals
-	expectedPa := 14.7 * 6894.76
+	expectedPa := 14.7 * 6894.757293168361
 
 	// Verify the value of direct creation matches expected
 	if math.Abs(pressure.Value-expectedPa) > 0.1 {
@@ -808,3 +830,424 @@ func TestPsiUnit(t *testing.T) {
 			kPa, expectedKPa)
 	}
 }
+
+// assertParse checks that Parse(input) yields the expected unit within a relative tolerance
+func assertParse(t *testing.T, input string, want si.Unit) {
+	t.Helper()
+
+	got, err := si.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", input, err)
+	}
+	if got.Dimension != want.Dimension {
+		t.Errorf("Parse(%q) dimension = %v, want %v", input, got.Dimension, want.Dimension)
+	}
+	if math.Abs(got.Value-want.Value) > 1e-12*math.Max(1, math.Abs(want.Value)) {
+		t.Errorf("Parse(%q) value = %v, want %v", input, got.Value, want.Value)
+	}
+}
+
+// TestParseNegativeExponentScientific verifies negative scientific notation values
+func TestParseNegativeExponentScientific(t *testing.T) {
+	assertParse(t, "1e-3 m", si.Meters(1e-3))
+}
+
+// TestParseScientificWithoutUnit verifies a bare scientific number is dimensionless
+func TestParseScientificWithoutUnit(t *testing.T) {
+	assertParse(t, "1e-3", si.Scalar(1e-3))
+}
+
+// TestParseNegativeValue verifies a leading minus sign on the value
+func TestParseNegativeValue(t *testing.T) {
+	assertParse(t, "-2.5 kW", si.Watts(-2500))
+}
+
+// TestParseTimesTenNotation verifies values written as "1.5×10^3"
+func TestParseTimesTenNotation(t *testing.T) {
+	assertParse(t, "1.5×10^3 m", si.Meters(1500))
+}
+
+// TestParseTimesTenSuperscript verifies values written as "4.7×10⁻⁶"
+func TestParseTimesTenSuperscript(t *testing.T) {
+	assertParse(t, "4.7×10⁻⁶ A", si.Amperes(4.7e-6))
+}
+
+// TestParseSuperscriptUnit verifies superscript exponents in the unit
+func TestParseSuperscriptUnit(t *testing.T) {
+	assertParse(t, "3 m²", si.Meter.Pow(2).Mul(si.Scalar(3)))
+}
+
+// TestParseNegativeSuperscriptUnit verifies negative superscript exponents
+func TestParseNegativeSuperscriptUnit(t *testing.T) {
+	assertParse(t, "50 s⁻¹", si.Hertzs(50))
+}
+
+// TestParseNegativeExponentUnit verifies caret exponents with a sign
+func TestParseNegativeExponentUnit(t *testing.T) {
+	assertParse(t, "2 kg*m^-2", si.Kilograms(2).Div(si.Meter.Pow(2)))
+}
+
+// TestParseGluedValue verifies a value written directly against its unit
+func TestParseGluedValue(t *testing.T) {
+	assertParse(t, "100km/h", si.Meter.Div(si.Second).Mul(si.Scalar(100000.0/3600.0)))
+}
+
+// TestParseSpacedUnit verifies spaces inside the unit expression are ignored
+func TestParseSpacedUnit(t *testing.T) {
+	assertParse(t, "9.81 m / s ^ 2", si.Meter.Div(si.Second.Pow(2)).Mul(si.Scalar(9.81)))
+}
+
+// TestParseNaN verifies the non-finite values produced by the formatter parse back
+func TestParseNaN(t *testing.T) {
+	got, err := si.Parse("NaN m")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !math.IsNaN(got.Value) || got.Dimension != si.Length {
+		t.Errorf("Parse(NaN m) = %+v, want NaN m", got)
+	}
+}
+
+// TestParseInfinity verifies infinite values parse with their unit
+func TestParseInfinity(t *testing.T) {
+	got, err := si.Parse("-Inf W")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !math.IsInf(got.Value, -1) || got.Dimension != si.Watt.Dimension {
+		t.Errorf("Parse(-Inf W) = %+v, want -Inf W", got)
+	}
+}
+
+// TestParseMissingValue verifies a unit without a leading number is rejected
+func TestParseMissingValue(t *testing.T) {
+	if _, err := si.Parse("km/h"); err == nil {
+		t.Error("Expected error for input without a value")
+	}
+}
+
+// TestParseBytesHaveInformationDimension verifies bytes are not plain numbers
+func TestParseBytesHaveInformationDimension(t *testing.T) {
+	got, err := si.Parse("15 MB")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got.Dimension != si.Information || got.Value != 15e6 {
+		t.Errorf("Parse(15 MB) = %+v, want 15e6 B", got)
+	}
+	if got.Dimension == si.Dimensionless {
+		t.Error("Expected bytes to differ from dimensionless numbers")
+	}
+}
+
+// TestParseBitsConvertToBytes verifies a bit is an eighth of a byte
+func TestParseBitsConvertToBytes(t *testing.T) {
+	got, err := si.Parse("2 Kibit")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got.Value != 256 || got.Dimension != si.Information {
+		t.Errorf("Parse(2 Kibit) = %+v, want 256 B", got)
+	}
+}
+
+// TestDataRateIsNotFrequency verifies bandwidth keeps its information dimension
+func TestDataRateIsNotFrequency(t *testing.T) {
+	got, err := si.Parse("100 Mbit/s")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got.Dimension == si.Hertz.Dimension {
+		t.Error("Expected a data rate to differ from a frequency")
+	}
+	if got.Value != 12.5e6 {
+		t.Errorf("Value = %v, want 12.5e6 B/s", got.Value)
+	}
+}
+
+// TestParseInformationPrefixes verifies bytes and bits only take multiples,
+// so that dB and cB are not read as fractions of a byte
+func TestParseInformationPrefixes(t *testing.T) {
+	for _, input := range []string{"1 dB", "1 cB", "1 mB", "1 dbit", "1 µbit"} {
+		if got, err := si.Parse(input); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", input, got)
+		}
+	}
+	for _, input := range []string{"1 kB", "1 MiB", "1 Gbit", "1 Tibit", "1 iB"} {
+		if _, err := si.Parse(input); err != nil {
+			t.Errorf("Parse(%q) error: %v", input, err)
+		}
+	}
+}
+
+// TestDimensionlessInformation verifies bytes can be registered as plain numbers
+func TestDimensionlessInformation(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{DimensionlessInformation: true})
+	got, err := ctx.Parse("2 kB")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got != si.Scalar(2000) {
+		t.Errorf("Parse(2 kB) = %+v, want 2000", got)
+	}
+	if got, err := ctx.Parse("8 bit"); err != nil || got != si.Scalar(1) {
+		t.Errorf("Parse(8 bit) = %+v, %v, want 1", got, err)
+	}
+}
+
+// TestBitsConstructor verifies Bits converts to bytes
+func TestBitsConstructor(t *testing.T) {
+	if got := si.Bits(32); got != si.Bytes(4) {
+		t.Errorf("Bits(32) = %+v, want %+v", got, si.Bytes(4))
+	}
+}
+
+// TestDataConstructorsScale verifies the data constructors convert to bytes
+func TestDataConstructorsScale(t *testing.T) {
+	if got := si.Megabytes(15); got.Value != 15e6 || got.Dimension != si.Information {
+		t.Errorf("Megabytes(15) = %+v, want 15e6 B", got)
+	}
+	if got := si.Kibibytes(2); got.Value != 2048 || got.Dimension != si.Information {
+		t.Errorf("Kibibytes(2) = %+v, want 2048 B", got)
+	}
+}
+
+// TestParseDegreesToRadians verifies degrees convert to radians
+func TestParseDegreesToRadians(t *testing.T) {
+	got, err := si.MustParse("90 °").In("rad")
+	if err != nil {
+		t.Fatalf("In error: %v", err)
+	}
+	if math.Abs(got-math.Pi/2) > 1e-12 {
+		t.Errorf("90 ° = %v rad, want %v", got, math.Pi/2)
+	}
+}
+
+// TestParseAngleAliases verifies the spelled-out angle units agree with their symbols
+func TestParseAngleAliases(t *testing.T) {
+	pairs := [][2]string{{"1 deg", "1 °"}, {"1 arcmin", "1 ′"}, {"1 arcsec", "1 ″"}}
+	for _, pair := range pairs {
+		if a, b := si.MustParse(pair[0]), si.MustParse(pair[1]); a != b {
+			t.Errorf("%s = %+v, want %+v", pair[0], a, b)
+		}
+	}
+}
+
+// TestParseArcminutesToDegrees verifies sixty arcminutes make a degree
+func TestParseArcminutesToDegrees(t *testing.T) {
+	got, err := si.MustParse("90 arcmin").In("deg")
+	if err != nil {
+		t.Fatalf("In error: %v", err)
+	}
+	if math.Abs(got-1.5) > 1e-12 {
+		t.Errorf("90 arcmin = %v deg, want 1.5", got)
+	}
+}
+
+// TestParseGradiansToRevolutions verifies 400 gradians make a revolution
+func TestParseGradiansToRevolutions(t *testing.T) {
+	got, err := si.MustParse("100 grad").In("rev")
+	if err != nil {
+		t.Fatalf("In error: %v", err)
+	}
+	if math.Abs(got-0.25) > 1e-12 {
+		t.Errorf("100 grad = %v rev, want 0.25", got)
+	}
+}
+
+// TestParseRPMToRadiansPerSecond verifies rpm converts to rad/s
+func TestParseRPMToRadiansPerSecond(t *testing.T) {
+	got, err := si.MustParse("60 rpm").In("rad/s")
+	if err != nil {
+		t.Fatalf("In error: %v", err)
+	}
+	if math.Abs(got-2*math.Pi) > 1e-12 {
+		t.Errorf("60 rpm = %v rad/s, want 2π", got)
+	}
+}
+
+// TestAngleDimensionKeepsRadiansPerSecond verifies the opt-in angle dimension keeps rad/s apart from Hz
+func TestAngleDimensionKeepsRadiansPerSecond(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	omega, err := ctx.Parse("2 rad/s")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if omega.Dimension == si.Hertz.Dimension {
+		t.Error("Expected rad/s to differ from Hz")
+	}
+	if got := omega.String(); got != "2 rad/s" {
+		t.Errorf("String = %q, want %q", got, "2 rad/s")
+	}
+}
+
+// TestAngleDimensionDropAngle verifies DropAngle collapses rad/s to Hz when asked
+func TestAngleDimensionDropAngle(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	omega, err := ctx.Parse("2 rad/s")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := omega.DropAngle().String(); got != "2 Hz" {
+		t.Errorf("String = %q, want %q", got, "2 Hz")
+	}
+}
+
+// TestAngleDimensionSteradian verifies a steradian is a square radian
+func TestAngleDimensionSteradian(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	sr, err := ctx.ParseUnit("sr")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+	want := si.Unit{Value: 1, Dimension: si.Angle}.Pow(2).Dimension
+	if sr.Dimension != want {
+		t.Errorf("sr dimension = %v, want %v", sr.Dimension, want)
+	}
+}
+
+// TestAngleDimensionSteradianSymbol verifies solid angles are written in sr, not rad^2
+func TestAngleDimensionSteradianSymbol(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	sr, err := ctx.Parse("2 sr")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := sr.String(); got != "2 sr" {
+		t.Errorf("String = %q, want %q", got, "2 sr")
+	}
+	if got := si.MustParse("2 sr").String(); got != "2 sr" {
+		t.Errorf("String = %q, want %q", got, "2 sr")
+	}
+}
+
+// TestAngleDimensionIn verifies Registry.In resolves targets with the registry
+// the quantity came from
+func TestAngleDimensionIn(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	turn, err := ctx.Parse("1 rev")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if _, err := turn.In("rad"); err == nil {
+		t.Error("Expected the default registry to reject rad with an angle dimension")
+	}
+	got, err := ctx.In(turn, "rad")
+	if err != nil || math.Abs(got-2*math.Pi) > 1e-12 {
+		t.Errorf("In(rad) = %v, %v, want 2π", got, err)
+	}
+	if str, err := ctx.FormatAs(turn, "deg"); err != nil || str != "360 deg" {
+		t.Errorf("FormatAs(deg) = %q, %v, want %q", str, err, "360 deg")
+	}
+	if str, err := ctx.FormatIn(turn, "grad", 1); err != nil || str != "400.0 grad" {
+		t.Errorf("FormatIn(grad) = %q, %v, want %q", str, err, "400.0 grad")
+	}
+}
+
+// TestAngleDimensionRejectsPlainNumbers verifies angles cannot be added to numbers
+func TestAngleDimensionRejectsPlainNumbers(t *testing.T) {
+	ctx := si.NewContext(si.ContextOptions{AngleDimension: true})
+	angle, err := ctx.Parse("30 deg")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if _, err := angle.Add(si.Scalar(1)); err == nil {
+		t.Error("Expected error adding an angle to a plain number")
+	}
+}
+
+// TestDegreesConstructor verifies Degrees converts to radians
+func TestDegreesConstructor(t *testing.T) {
+	if got := si.Degrees(180); math.Abs(got.Value-math.Pi) > 1e-12 || got.Dimension != si.Dimensionless {
+		t.Errorf("Degrees(180) = %+v, want π", got)
+	}
+}
+
+// TestParseKiloohm verifies the ohm takes prefixes and keeps its dimension
+func TestParseKiloohm(t *testing.T) {
+	got, err := si.Parse("4.7 kΩ")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if math.Abs(got.Value-4700) > 1e-9 || got.Dimension != si.Ohm.Dimension {
+		t.Errorf("Parse(4.7 kΩ) = %+v, want 4700 Ω", got)
+	}
+	if s := got.String(); s != "4.7 kΩ" {
+		t.Errorf("String = %q, want %q", s, "4.7 kΩ")
+	}
+}
+
+// TestElectromagneticUnitsRoundTrip verifies the named electromagnetic units print as parsed
+func TestElectromagneticUnitsRoundTrip(t *testing.T) {
+	for _, input := range []string{"10 μF", "3 mH", "1.2 T", "5 mS", "2 nWb", "4 Ω", "8 kC"} {
+		got, err := si.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", input, err)
+		}
+		if s := got.String(); s != input {
+			t.Errorf("Parse(%q).String() = %q", input, s)
+		}
+	}
+}
+
+// TestDerivedUnitRelations verifies the derived units agree with their definitions
+func TestDerivedUnitRelations(t *testing.T) {
+	if si.Ohm.Mul(si.Siemens).Dimension != si.Dimensionless {
+		t.Errorf("Ω·S dimension = %v, want dimensionless", si.Ohm.Mul(si.Siemens).Dimension)
+	}
+	if got := si.Henry.Mul(si.Farad).Dimension; got != si.Second.Pow(2).Dimension {
+		t.Errorf("H·F dimension = %v, want T^2", got)
+	}
+	if got := si.Tesla.Mul(si.Meter.Pow(2)).Dimension; got != si.Weber.Dimension {
+		t.Errorf("T·m² dimension = %v, want %v", got, si.Weber.Dimension)
+	}
+}
+
+// TestParseRadiationAndCatalysisUnits verifies Bq, Gy, Sv and kat
+func TestParseRadiationAndCatalysisUnits(t *testing.T) {
+	tests := map[string]si.Unit{
+		"3 kBq":   si.Hertz.Mul(si.Scalar(3000)),
+		"2 mGy":   si.Gray.Mul(si.Scalar(2e-3)),
+		"2 mSv":   si.Gray.Mul(si.Scalar(2e-3)),
+		"5 μkat":  si.Katal.Mul(si.Scalar(5e-6)),
+		"10 lm":   si.Candela.Mul(si.Scalar(10)),
+		"10 lx":   si.Candela.Div(si.Meter.Pow(2)).Mul(si.Scalar(10)),
+		"1 ohm":   si.Ohm,
+		"1 mSv/h": si.Gray.Mul(si.Scalar(1e-3)).Div(si.Hours(1)),
+	}
+	for input, want := range tests {
+		got, err := si.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", input, err)
+		}
+		if got.Dimension != want.Dimension || math.Abs(got.Value-want.Value) > 1e-12*math.Abs(want.Value) {
+			t.Errorf("Parse(%q) = %+v, want %+v", input, got, want)
+		}
+	}
+}
+
+// TestParseAcceptedNonSIUnits verifies the units accepted for use with the SI
+func TestParseAcceptedNonSIUnits(t *testing.T) {
+	tests := map[string]si.Unit{
+		"2 L":        si.Meter.Pow(3).Mul(si.Scalar(2e-3)),
+		"250 ml":     si.Meter.Pow(3).Mul(si.Scalar(2.5e-4)),
+		"3 ha":       si.Meter.Pow(2).Mul(si.Scalar(3e4)),
+		"2.5 mbar":   si.Pascals(250),
+		"1 keV":      si.Joules(1.602176634e-16),
+		"12 kDa":     si.Kilograms(12 * 1.66053906892e-27 * 1000),
+		"1 au":       si.Meters(149597870700),
+		"1.54 Å":     si.Meters(1.54e-10),
+		"1 angstrom": si.Meters(1e-10),
+		"1.5 kWh":    si.Joules(5.4e6),
+		"2 t":        si.Kilograms(2000),
+	}
+	for input, want := range tests {
+		got, err := si.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", input, err)
+		}
+		if got.Dimension != want.Dimension || math.Abs(got.Value-want.Value) > 1e-12*math.Abs(want.Value) {
+			t.Errorf("Parse(%q) = %+v, want %+v", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package si
+
+import "slices"
+
+// UnitFactor is a unit symbol raised to an integer power, one factor of a unit
+// written as a product such as W/(m^2*K)
+type UnitFactor struct {
+	Symbol string
+	Exp    int
+	// Base reports whether Symbol is a base unit such as m or kg rather than a
+	// named unit such as W
+	Base bool
+}
+
+// SimplifyOptions configures the search Simplify runs.
+// The zero value uses the named SI units and SimplifyScore.
+type SimplifyOptions struct {
+	// Units lists the symbols of the named units the search may use, such as "W"
+	// or "Pa". They are resolved with the default registry and must be coherent
+	// SI units, without prefix or scale. Nil uses DefaultSimplifyUnits.
+	Units []string
+	// MaxNamed is the largest number of named units in a result, 2 when zero
+	MaxNamed int
+	// Score rates a candidate; the lowest score wins. Nil uses SimplifyScore.
+	Score func([]UnitFactor) int
+}
+
+// DefaultSimplifyUnits returns the symbols of the named SI units Simplify uses
+// unless SimplifyOptions.Units lists others. Units that share a dimension with
+// another, such as Bq and Gy, are left out as in the default KnownSymbols.
+func DefaultSimplifyUnits() []string {
+	return []string{"N", "J", "W", "Pa", "Hz", "C", "V", "Ω", "S", "F", "H", "Wb", "T"}
+}
+
+// simplifyExponents are the powers a named unit may appear with in a result
+var simplifyExponents = []int{1, -1, 2, -2}
+
+// SimplifyScore is the default score of Simplify. Each factor costs 4, plus 2 for
+// every step of its exponent, so W/(m^2*K) scores 4+2 + 4+4 + 4+2 = 20 before
+// the costs for named units. A named unit costs 2 more, so that acceleration stays
+// m/s^2 rather than N/kg, and a cube or higher power of a base unit other than the
+// metre costs 10 more, because it usually hides a named unit: kg/s^3 is W/m^2.
+func SimplifyScore(factors []UnitFactor) int {
+	score := 0
+	for _, f := range factors {
+		score += 4 + 2*abs(f.Exp)
+		switch {
+		case !f.Base:
+			score += 2
+		case f.Symbol != "m" && abs(f.Exp) >= 3:
+			score += 10
+		}
+	}
+	return score
+}
+
+// namedUnit is a named unit the search may use
+type namedUnit struct {
+	symbol string
+	dim    Dimension
+	// cover is the sum of the absolute base exponents of dim, 6 for W
+	cover int
+}
+
+// newNamedUnit returns the named unit with its cover
+func newNamedUnit(symbol string, dim Dimension) namedUnit {
+	cover := 0
+	for i := 0; i < numBaseDimensions; i++ {
+		cover += abs(dim.numerator(i))
+	}
+	return namedUnit{symbol: symbol, dim: dim, cover: cover}
+}
+
+// Simplify writes an integer dimension as the product of named units and base
+// units with the lowest score, e.g. kg*m^2/(s^3*A) as V and kg/(s^3*K) as
+// W/(m^2*K). The factors are ordered with named units first, then the base
+// units in the order the formatter writes them. opts may be nil. The result
+// never scores worse than the dimension written in base units alone, which is
+// returned when no named unit improves on it.
+// Simplify reports false for dimensionless and fractional dimensions.
+//
+// Example:
+//
+//	factors, _ := si.Simplify(si.Newton.Mul(si.Meter).Div(si.Second).Dimension, nil) // [{W 1 false}]
+func Simplify(d Dimension, opts *SimplifyOptions) ([]UnitFactor, bool) {
+	if d == Dimensionless || !d.IsInteger() {
+		return nil, false
+	}
+	if opts == nil {
+		opts = &SimplifyOptions{}
+	}
+	score := opts.Score
+	if score == nil {
+		score = SimplifyScore
+	}
+	maxNamed := opts.MaxNamed
+	if maxNamed == 0 {
+		maxNamed = 2
+	}
+
+	s := simplifier{units: resolveSimplifyUnits(opts.Units), score: score}
+	s.best = baseFactors(d)
+	s.bestScore = score(s.best)
+	s.bestNamed = 0
+	s.search(d, nil, 0, 0, maxNamed)
+	return s.best, true
+}
+
+// simplifier holds the state of the search for the best factors
+type simplifier struct {
+	units     []namedUnit
+	score     func([]UnitFactor) int
+	best      []UnitFactor
+	bestScore int
+	bestNamed int
+	// bestCover is the sum of the exponents the named units of best stand for
+	bestCover int
+}
+
+// search tries every combination of named units from index start onwards, up
+// to depth more of them, writing the rest of d in base units
+func (s *simplifier) search(d Dimension, named []UnitFactor, cover, start, depth int) {
+	if depth == 0 {
+		return
+	}
+	for i := start; i < len(s.units); i++ {
+		for _, exp := range simplifyExponents {
+			rest := divDimensions(d, powDimension(s.units[i].dim, exp, 1))
+			candidate := append(slices.Clip(named), UnitFactor{Symbol: s.units[i].symbol, Exp: exp})
+			s.consider(append(slices.Clip(candidate), baseFactors(rest)...), len(candidate), cover+s.units[i].cover*abs(exp))
+			s.search(rest, candidate, cover+s.units[i].cover*abs(exp), i+1, depth-1)
+		}
+	}
+}
+
+// consider keeps the candidate if it scores lower than the best so far. Among
+// equal scores, a result with a numerator wins over one written as 1/..., so the
+// specific charge is C/kg rather than 1/(T*s). Then fewer named units win, and
+// then named units that stand for more of the dimension, so thermal
+// conductivity is W/(m*K) rather than N/(s*K).
+func (s *simplifier) consider(factors []UnitFactor, named, cover int) {
+	score := s.score(factors)
+	numerator, bestNumerator := hasNumerator(factors), hasNumerator(s.best)
+	switch {
+	case score > s.bestScore:
+		return
+	case score == s.bestScore && numerator != bestNumerator:
+		if !numerator {
+			return
+		}
+	case score == s.bestScore && named > s.bestNamed:
+		return
+	case score == s.bestScore && named == s.bestNamed && cover <= s.bestCover:
+		return
+	}
+	s.best, s.bestScore, s.bestNamed, s.bestCover = factors, score, named, cover
+}
+
+// hasNumerator reports whether any factor has a positive exponent
+func hasNumerator(factors []UnitFactor) bool {
+	return slices.ContainsFunc(factors, func(f UnitFactor) bool { return f.Exp > 0 })
+}
+
+// resolveSimplifyUnits looks up the dimensions of the named units. The default
+// set is read from standardSymbols; other symbols are parsed with the default
+// registry, skipping those that are not coherent SI units.
+func resolveSimplifyUnits(symbols []string) []namedUnit {
+	if symbols == nil {
+		bySymbol := make(map[string]Dimension, len(standardSymbols))
+		for dim, symbol := range standardSymbols {
+			bySymbol[symbol] = dim
+		}
+		units := make([]namedUnit, 0, len(bySymbol))
+		for _, symbol := range DefaultSimplifyUnits() {
+			if dim, ok := bySymbol[symbol]; ok {
+				units = append(units, newNamedUnit(symbol, dim))
+			}
+		}
+		return units
+	}
+
+	units := make([]namedUnit, 0, len(symbols))
+	for _, symbol := range symbols {
+		u, err := ParseUnit(symbol)
+		if err != nil || u.Value != 1 || u.IsAbsolute() || !u.Dimension.IsInteger() || u.Dimension == Dimensionless {
+			continue
+		}
+		units = append(units, newNamedUnit(symbol, u.Dimension))
+	}
+	return units
+}
+
+// baseFactors writes an integer dimension in base units, in the order of numeratorOrder
+func baseFactors(d Dimension) []UnitFactor {
+	var factors []UnitFactor
+	for _, i := range numeratorOrder {
+		if num, _ := d.Exponent(i); num != 0 {
+			factors = append(factors, UnitFactor{Symbol: baseUnitSymbol(i), Exp: num, Base: true})
+		}
+	}
+	return factors
+}
+
+// factorsToAST builds the AST of a product of factors in their order, with the
+// negative powers in the denominator: W/(m^2*K), J/(kg*K)
+func factorsToAST(factors []UnitFactor) Node {
+	var numerator, denominator []Node
+	for _, f := range factors {
+		if f.Exp > 0 {
+			numerator = append(numerator, dimensionFactor(f.Symbol, f.Exp, 1))
+		} else {
+			denominator = append(denominator, dimensionFactor(f.Symbol, -f.Exp, 1))
+		}
+	}
+	return fractionNode(numerator, denominator)
+}
+
+// hasNamedUnit reports whether any factor is a named unit. Results in base
+// units alone are written by dimensionToAST, as without simplification.
+func hasNamedUnit(factors []UnitFactor) bool {
+	return slices.ContainsFunc(factors, func(f UnitFactor) bool { return !f.Base })
+}
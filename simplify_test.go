@@ -0,0 +1,206 @@
+package si_test
+
+import (
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// simplified formats a dimension with the default formatter and Simplify set
+func simplified(t *testing.T, d si.Dimension) string {
+	t.Helper()
+	f := si.NewDefaultFormatter()
+	f.Options.Simplify = true
+	str, err := f.FormatDimension(d)
+	if err != nil {
+		t.Fatalf("FormatDimension(%v) error: %v", d, err)
+	}
+	return str
+}
+
+// TestSimplifyNamedUnits verifies that compound dimensions collapse into the
+// named units they are made of
+func TestSimplifyNamedUnits(t *testing.T) {
+	tests := []struct {
+		unit si.Unit
+		want string
+	}{
+		{si.Kilogram.Mul(si.Meter.Pow(2)).Div(si.Second.Pow(3).Mul(si.Ampere)), "V"},
+		{si.Volt.Mul(si.Ampere), "W"},
+		{si.Newton.Mul(si.Meter).Div(si.Second), "W"},
+		{si.Watt.Div(si.Meter.Pow(2).Mul(si.Kelvin)), "W/(m^2*K)"},
+		{si.Watt.Div(si.Meter.Mul(si.Kelvin)), "W/(m*K)"},
+		{si.Joule.Div(si.Kilogram.Mul(si.Kelvin)), "J/(kg*K)"},
+		{si.Pascal.Div(si.Meter), "Pa/m"},
+		{si.Volt.Div(si.Meter), "V/m"},
+		{si.Ampere.Div(si.Meter.Pow(2)), "A/m^2"},
+		{si.Coulomb.Div(si.Kilogram), "C/kg"},
+		{si.Pascal.Mul(si.Second).Inv(), "1/(Pa*s)"},
+		{si.Newton.Inv(), "1/N"},
+	}
+	for _, tt := range tests {
+		if got := simplified(t, tt.unit.Dimension); got != tt.want {
+			t.Errorf("simplified(%v) = %q, want %q", tt.unit.Dimension, got, tt.want)
+		}
+	}
+}
+
+// TestSimplifyKeepsBaseUnits verifies that dimensions that read best in base
+// units are not forced into named units
+func TestSimplifyKeepsBaseUnits(t *testing.T) {
+	tests := []struct {
+		unit si.Unit
+		want string
+	}{
+		{si.Meter.Div(si.Second.Pow(2)), "m/s^2"},
+		{si.Meter.Pow(3).Div(si.Second), "m^3/s"},
+		{si.Kilogram.Div(si.Meter.Pow(3)), "kg/m^3"},
+		{si.Meter.Div(si.Second), "m/s"},
+	}
+	for _, tt := range tests {
+		if got := simplified(t, tt.unit.Dimension); got != tt.want {
+			t.Errorf("simplified(%v) = %q, want %q", tt.unit.Dimension, got, tt.want)
+		}
+	}
+}
+
+// TestSimplifyFactors verifies the factors Simplify returns
+func TestSimplifyFactors(t *testing.T) {
+	factors, ok := si.Simplify(si.Watt.Div(si.Meter.Pow(2).Mul(si.Kelvin)).Dimension, nil)
+	if !ok {
+		t.Fatal("Simplify() reported false")
+	}
+	want := []si.UnitFactor{{Symbol: "W", Exp: 1}, {Symbol: "m", Exp: -2, Base: true}, {Symbol: "K", Exp: -1, Base: true}}
+	if len(factors) != len(want) {
+		t.Fatalf("Simplify() = %v, want %v", factors, want)
+	}
+	for i := range want {
+		if factors[i] != want[i] {
+			t.Errorf("Simplify()[%d] = %v, want %v", i, factors[i], want[i])
+		}
+	}
+
+	if _, ok := si.Simplify(si.Dimensionless, nil); ok {
+		t.Error("Simplify(Dimensionless) reported true")
+	}
+	if _, ok := si.Simplify(si.Meter.PowRat(1, 2).Dimension, nil); ok {
+		t.Error("Simplify() of a fractional dimension reported true")
+	}
+}
+
+// TestSimplifyOptionsUnits verifies that the search only uses the allowed units
+func TestSimplifyOptionsUnits(t *testing.T) {
+	f := si.NewDefaultFormatter()
+	f.Options.Simplify = true
+	f.Options.CollapseSymbols = false
+	f.Options.SimplifyOptions.Units = []string{"J"}
+
+	tests := []struct {
+		unit si.Unit
+		want string
+	}{
+		{si.Watt, "J/s"},
+		{si.Volt.Mul(si.Ampere), "J/s"},
+		{si.Newton, "J/m"},
+	}
+	for _, tt := range tests {
+		got, err := f.FormatDimension(tt.unit.Dimension)
+		if err != nil {
+			t.Fatalf("FormatDimension(%v) error: %v", tt.unit.Dimension, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatDimension(%v) = %q, want %q", tt.unit.Dimension, got, tt.want)
+		}
+	}
+}
+
+// TestSimplifyOptionsScore verifies that a custom score decides between candidates
+func TestSimplifyOptionsScore(t *testing.T) {
+	// Without the cost of named units, N/kg beats m/s^2
+	powers := func(factors []si.UnitFactor) int {
+		score := 0
+		for _, f := range factors {
+			score += 1 + max(f.Exp, -f.Exp)
+		}
+		return score
+	}
+	factors, _ := si.Simplify(si.Meter.Div(si.Second.Pow(2)).Dimension, &si.SimplifyOptions{Score: powers})
+	if len(factors) != 2 || factors[0].Symbol != "N" || factors[1].Symbol != "kg" {
+		t.Errorf("Simplify() = %v, want N/kg", factors)
+	}
+}
+
+// TestFormatASTSimplify verifies that the formatter rewrites unit expressions
+// only when Simplify is set
+func TestFormatASTSimplify(t *testing.T) {
+	tests := map[string]string{
+		"V*A":        "W",
+		"N*m/s":      "W",
+		"J/s":        "W",
+		"kg*m/s^2":   "N",
+		"W/(m^2*K)":  "W/(m^2*K)",
+		"km/h":       "km/h",
+		"V":          "V",
+		"N*m":        "N*m",
+		"kg*m^2/s^2": "J",
+	}
+	f := si.NewDefaultFormatter()
+	f.Options.Simplify = true
+	for input, want := range tests {
+		node, err := si.ParseUnitAST(input)
+		if err != nil {
+			t.Fatalf("ParseUnitAST(%q) error: %v", input, err)
+		}
+		got, err := f.Format(node)
+		if err != nil {
+			t.Fatalf("Format(%q) error: %v", input, err)
+		}
+		if got != want {
+			t.Errorf("Format(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	node, _ := si.ParseUnitAST("V*A")
+	if got, _ := si.NewDefaultFormatter().Format(node); got != "V*A" {
+		t.Errorf("Format(%q) without Simplify = %q, want %q", "V*A", got, "V*A")
+	}
+}
+
+// TestFormatUnitWithOptionsSimplify verifies that FormatUnitWithOptions honors Simplify
+func TestFormatUnitWithOptionsSimplify(t *testing.T) {
+	u := si.Watt.Div(si.Meter.Pow(2).Mul(si.Kelvin)).Mul(si.Scalar(5))
+
+	opts := si.DefaultFormatOptions()
+	if got := si.FormatUnitWithOptions(u, &opts); got != "5 kg/(s^3*K)" {
+		t.Errorf("FormatUnitWithOptions() = %q, want %q", got, "5 kg/(s^3*K)")
+	}
+
+	opts.Simplify = true
+	if got := si.FormatUnitWithOptions(u, &opts); got != "5 W/(m^2*K)" {
+		t.Errorf("FormatUnitWithOptions() with Simplify = %q, want %q", got, "5 W/(m^2*K)")
+	}
+}
+
+// TestSimplifyNeverWorseThanBaseUnits verifies that Simplify does not return a
+// result that scores worse than the dimension in base units alone
+func TestSimplifyNeverWorseThanBaseUnits(t *testing.T) {
+	baseOnly := &si.SimplifyOptions{Units: []string{}}
+	for m := -2; m <= 2; m++ {
+		for kg := -2; kg <= 2; kg++ {
+			for s := -3; s <= 3; s++ {
+				for a := -1; a <= 1; a++ {
+					d := si.NewDimension(m, kg, s, a)
+					if d == si.Dimensionless {
+						continue
+					}
+					got, _ := si.Simplify(d, nil)
+					base, _ := si.Simplify(d, baseOnly)
+					if si.SimplifyScore(got) > si.SimplifyScore(base) {
+						t.Errorf("Simplify(%v) = %v scores %d, worse than %v at %d",
+							d, got, si.SimplifyScore(got), base, si.SimplifyScore(base))
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package si
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// Scan implements sql.Scanner for a unit stored as text such as "101.325 kPa",
+// the layout TextColumn writes. Symbols are resolved with the default registry.
+// NULL is an error; scan into sql.Null[si.Unit] for nullable columns.
+//
+// Unit cannot implement driver.Valuer itself, because its Value field takes the
+// name of the method. Pass a TextColumn, FloatColumn or BaseColumns as the query
+// argument instead.
+//
+// Example:
+//
+//	var pressure si.Unit
+//	err := db.QueryRow("SELECT pressure FROM readings WHERE id = $1", id).Scan(&pressure)
+func (u *Unit) Scan(src any) error {
+	var input string
+	switch v := src.(type) {
+	case string:
+		input = v
+	case []byte:
+		input = string(v)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Unit")
+	default:
+		return fmt.Errorf("cannot scan %T into Unit", src)
+	}
+
+	parsed, err := Parse(input)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the dimension as text like "L^-1·M·T^-2"
+func (d Dimension) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner, reading a dimension written by Dimension.String
+func (d *Dimension) Scan(src any) error {
+	var input string
+	switch v := src.(type) {
+	case string:
+		input = v
+	case []byte:
+		input = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Dimension", src)
+	}
+
+	parsed, err := ParseDimension(input)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// TextColumn stores a unit in a single text column as MarshalText writes it,
+// e.g. "101325 Pa", and reads it back with Parse. The text is lossless: it keeps
+// the value bit for bit, the scale of absolute temperatures ("21.5 °C") and the
+// kind of units such as "N*m".
+//
+// Example:
+//
+//	db.Exec("INSERT INTO readings (pressure) VALUES ($1)", si.TextColumn{Unit: pressure})
+//
+//	col := si.TextColumn{Expect: si.Pascal}
+//	err := db.QueryRow("SELECT pressure FROM readings").Scan(&col) // col.Unit is a pressure
+type TextColumn struct {
+	Unit Unit
+	// Expect, when set, is a unit of the dimension Scan requires
+	Expect Unit
+}
+
+// Value implements driver.Valuer
+func (c TextColumn) Value() (driver.Value, error) {
+	text, err := c.Unit.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner, returning a DimensionMismatchError when the text
+// is not of the expected dimension
+func (c *TextColumn) Scan(src any) error {
+	var u Unit
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+	if err := checkScanned(c.Expect, u.Dimension); err != nil {
+		return err
+	}
+	c.Unit = u
+	return nil
+}
+
+// FloatColumn stores a unit in a numeric column as its value in a fixed unit,
+// such as a pressure_kpa column holding 101.325. In is any expression ParseUnit
+// accepts, including affine scales such as "°C", and also fixes the dimension
+// that Value and Scan accept.
+//
+// Example:
+//
+//	db.Exec("INSERT INTO readings (pressure_kpa) VALUES ($1)", si.FloatColumn{Unit: pressure, In: "kPa"})
+//
+//	col := si.FloatColumn{In: "kPa"}
+//	err := db.QueryRow("SELECT pressure_kpa FROM readings").Scan(&col)
+type FloatColumn struct {
+	Unit Unit
+	// In is the unit the column is stored in
+	In string
+}
+
+// Value implements driver.Valuer, returning a DimensionMismatchError when the
+// unit cannot be expressed in In
+func (c FloatColumn) Value() (driver.Value, error) {
+	return c.Unit.In(c.In)
+}
+
+// Scan implements sql.Scanner
+func (c *FloatColumn) Scan(src any) error {
+	val, err := scanFloat(src)
+	if err != nil {
+		return err
+	}
+	unit, err := ParseUnit(c.In)
+	if err != nil {
+		return err
+	}
+	c.Unit = applyValue(unit, val)
+	return nil
+}
+
+// BaseColumns stores a unit in two columns: the value in base SI units as a
+// float, and the dimension as text like "L^-1·M·T^-2". Nothing is lost to
+// formatting or to the choice of a unit, but absolute temperatures are stored
+// as kelvins and quantity kinds are not kept.
+//
+// Example:
+//
+//	col := si.BaseColumns{Unit: pressure}
+//	db.Exec("INSERT INTO readings (value, dimension) VALUES ($1, $2)", col.Args()...)
+//
+//	col = si.BaseColumns{Expect: si.Pascal}
+//	err := db.QueryRow("SELECT value, dimension FROM readings").Scan(col.Dest()...)
+type BaseColumns struct {
+	Unit Unit
+	// Expect, when set, is a unit of the dimension Scan requires
+	Expect Unit
+}
+
+// Args returns the value and the dimension as query arguments
+func (c *BaseColumns) Args() []any {
+	return []any{c.Unit.Value, c.Unit.Dimension}
+}
+
+// Dest returns the destinations of the value and dimension columns for
+// Rows.Scan. Scanning the dimension checks it against Expect.
+func (c *BaseColumns) Dest() []any {
+	return []any{&c.Unit.Value, (*baseDimension)(c)}
+}
+
+// baseDimension scans the dimension column of BaseColumns
+type baseDimension BaseColumns
+
+// Scan implements sql.Scanner
+func (c *baseDimension) Scan(src any) error {
+	var d Dimension
+	if err := d.Scan(src); err != nil {
+		return err
+	}
+	if err := checkScanned(c.Expect, d); err != nil {
+		return err
+	}
+	c.Unit.Dimension = d
+	c.Unit.Offset = 0
+	c.Unit.Kind = KindNone
+	return nil
+}
+
+// checkScanned reports a DimensionMismatchError if a scanned dimension is not
+// that of the expected unit. The zero Unit expects nothing.
+func checkScanned(expect Unit, d Dimension) error {
+	if expect == (Unit{}) || expect.Dimension == d {
+		return nil
+	}
+	return &DimensionMismatchError{Op: "scan", Left: d, Right: expect.Dimension}
+}
+
+// scanFloat converts the value of a numeric column, which drivers such as
+// SQLite may also return as text
+func scanFloat(src any) (float64, error) {
+	switch v := src.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case []byte:
+		return strconv.ParseFloat(string(v), 64)
+	case nil:
+		return 0, fmt.Errorf("cannot scan NULL into a unit")
+	default:
+		return 0, fmt.Errorf("cannot scan %T into a unit", src)
+	}
+}
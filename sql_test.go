@@ -0,0 +1,157 @@
+package si_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// Unit is read straight from a text column, and the column types write it
+var (
+	_ sql.Scanner   = (*si.Unit)(nil)
+	_ driver.Valuer = si.TextColumn{}
+	_ driver.Valuer = si.FloatColumn{}
+	_ driver.Valuer = si.Dimension{}
+)
+
+// TestUnitScan verifies that Scan parses text and byte columns
+func TestUnitScan(t *testing.T) {
+	var u si.Unit
+	if err := u.Scan("101.325 kPa"); err != nil || !u.Equals(si.Pascals(101325)) {
+		t.Errorf("Scan(string) = %v, %v, want 101.325 kPa", u, err)
+	}
+	if err := u.Scan([]byte("21.5 °C")); err != nil || u.String() != "21.5 °C" {
+		t.Errorf("Scan([]byte) = %v, %v, want 21.5 °C", u, err)
+	}
+	if err := u.Scan(nil); err == nil {
+		t.Error("Scan(nil) succeeded, want error")
+	}
+	if err := u.Scan(int64(3)); err == nil {
+		t.Error("Scan(int64) succeeded, want error")
+	}
+}
+
+// TestUnitScanNull verifies that sql.Null reads nullable columns
+func TestUnitScanNull(t *testing.T) {
+	var n sql.Null[si.Unit]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Scan(nil) = %v, valid %v, want NULL", err, n.Valid)
+	}
+	if err := n.Scan("3 m"); err != nil || !n.Valid || !n.V.Equals(si.Meters(3)) {
+		t.Errorf("Scan(%q) = %v, %v", "3 m", n.V, err)
+	}
+}
+
+// TestTextColumnRoundTrip verifies that units survive the text layout, including
+// values of 1 and units without a named symbol
+func TestTextColumnRoundTrip(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"101.325 kPa", "101325 Pa"},
+		{"21.5 °C", "21.5 °C"},
+		{"5 kN*m", "5000 N*m"},
+		{"3 MB/s", "3e+06 B/s"},
+		{"42 W/(m^2*K)", "42 W/(m^2*K)"},
+		{"1 W/(m^2*K)", "1 W/(m^2*K)"},
+		{"1 mol/s", "1 mol/s"},
+		{"1 m", "1 m"},
+		{"1", "1"},
+		{"0.1 m/s", "0.1 m/s"},
+		{"1 C/kg", "1 C/kg"},
+	}
+	for _, tt := range tests {
+		u := si.MustParse(tt.input)
+		v, err := si.TextColumn{Unit: u}.Value()
+		if err != nil {
+			t.Fatalf("Value(%q) error: %v", tt.input, err)
+		}
+		if v != tt.want {
+			t.Errorf("Value(%q) = %v, want %q", tt.input, v, tt.want)
+		}
+
+		var col si.TextColumn
+		if err := col.Scan(v); err != nil {
+			t.Fatalf("Scan(%q) error: %v", v, err)
+		}
+		if !sameUnit(col.Unit, u) {
+			t.Errorf("Scan(%q) = %#v, want %#v", v, col.Unit, u)
+		}
+	}
+}
+
+// TestTextColumnExpect verifies the dimension check on scan
+func TestTextColumnExpect(t *testing.T) {
+	col := si.TextColumn{Expect: si.Pascal}
+	if err := col.Scan("2.5 bar"); err != nil || !col.Unit.Equals(si.Pascals(250000)) {
+		t.Errorf("Scan(%q) = %v, %v", "2.5 bar", col.Unit, err)
+	}
+
+	err := col.Scan("3 m")
+	var mismatch *si.DimensionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Scan(%q) error = %v, want DimensionMismatchError", "3 m", err)
+	}
+	if !col.Unit.Equals(si.Pascals(250000)) {
+		t.Errorf("failed Scan changed the unit to %v", col.Unit)
+	}
+}
+
+// TestFloatColumn verifies the fixed-unit numeric layout
+func TestFloatColumn(t *testing.T) {
+	v, err := si.FloatColumn{Unit: si.Pascals(101325), In: "kPa"}.Value()
+	if err != nil || v != 101.325 {
+		t.Errorf("Value() = %v, %v, want 101.325", v, err)
+	}
+	if _, err := (si.FloatColumn{Unit: si.Meters(1), In: "kPa"}).Value(); err == nil {
+		t.Error("Value() of a length in kPa succeeded, want error")
+	}
+
+	col := si.FloatColumn{In: "°C"}
+	for _, src := range []any{21.5, "21.5", []byte("21.5")} {
+		if err := col.Scan(src); err != nil || col.Unit.String() != "21.5 °C" {
+			t.Errorf("Scan(%v) = %v, %v, want 21.5 °C", src, col.Unit, err)
+		}
+	}
+
+	col = si.FloatColumn{In: "kPa"}
+	if err := col.Scan(int64(350)); err != nil || !col.Unit.Equals(si.Pascals(350000)) {
+		t.Errorf("Scan(int64) = %v, %v, want 350 kPa", col.Unit, err)
+	}
+	if err := col.Scan(nil); err == nil {
+		t.Error("Scan(nil) succeeded, want error")
+	}
+}
+
+// TestBaseColumns verifies the value and dimension layout
+func TestBaseColumns(t *testing.T) {
+	col := si.BaseColumns{Unit: si.MustParse("101.325 kPa")}
+	args := col.Args()
+	if args[0] != 101325.0 {
+		t.Errorf("Args()[0] = %v, want 101325", args[0])
+	}
+	dim, err := args[1].(driver.Valuer).Value()
+	if err != nil || dim != "L^-1·M·T^-2" {
+		t.Errorf("Args()[1] = %v, %v, want L^-1·M·T^-2", dim, err)
+	}
+
+	// Rows.Scan assigns a float column to *float64 and calls Scan on the rest
+	read := si.BaseColumns{Expect: si.Pascal}
+	dest := read.Dest()
+	*dest[0].(*float64) = 101325
+	if err := dest[1].(sql.Scanner).Scan(dim); err != nil {
+		t.Fatalf("Scan(%v) error: %v", dim, err)
+	}
+	if !read.Unit.Equals(si.Pascals(101325)) {
+		t.Errorf("scanned %v, want 101.325 kPa", read.Unit)
+	}
+
+	read = si.BaseColumns{Expect: si.Meter}
+	if err := read.Dest()[1].(sql.Scanner).Scan(dim); err == nil {
+		t.Error("Scan() of a pressure into a length succeeded, want error")
+	}
+}
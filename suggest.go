@@ -0,0 +1,113 @@
+package si
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSuggestions caps the number of "did you mean" candidates in an UnknownUnitError
+const maxSuggestions = 3
+
+// suggestLocked returns the defined symbols closest to an unknown one, such as kPa
+// for "kPA". Prefixed forms are only generated for prefixes the symbol starts with,
+// ignoring case, so the search stays small. Only the best-scoring candidates are
+// returned, since a list of equally plausible guesses does not help an operator.
+// The caller must hold r.mu.
+func (r *Registry) suggestLocked(symbol string) []string {
+	n := utf8.RuneCountInString(symbol)
+	if n < 2 {
+		// Every one-letter symbol is a single edit away from any other
+		return nil
+	}
+	// Allow one edit per three runes, and at least one
+	limit := 2 * max(1, n/3)
+
+	best := limit + 1
+	var found []string
+	seen := make(map[string]bool)
+	consider := func(candidate string) {
+		if seen[candidate] || candidate == symbol {
+			return
+		}
+		seen[candidate] = true
+
+		switch d := symbolDistance(symbol, candidate); {
+		case d < best:
+			best = d
+			found = []string{candidate}
+		case d == best:
+			found = append(found, candidate)
+		}
+	}
+
+	for s := range r.units {
+		consider(s)
+	}
+	for a := range r.aliases {
+		consider(a)
+	}
+
+	lower := strings.ToLower(symbol)
+	for _, prefix := range r.sortedPrefixes {
+		if !strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			continue
+		}
+		p := r.prefixes[prefix]
+		for s, def := range r.units {
+			if def.opts.allows(prefix, p) {
+				consider(prefix + s)
+			}
+		}
+		for a, target := range r.aliases {
+			if r.units[target].opts.allows(prefix, p) {
+				consider(prefix + a)
+			}
+		}
+	}
+
+	sort.Strings(found)
+	if len(found) > maxSuggestions {
+		found = found[:maxSuggestions]
+	}
+	return found
+}
+
+// symbolDistance is the optimal string alignment distance between two symbols,
+// doubled so that a substitution differing only in case can cost 1. Unit symbols
+// are case-sensitive, but a case slip like "kPA" is the most common typo and
+// should rank ahead of any real edit.
+func symbolDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between ra[:i] and rb[:j]
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = 2 * i
+	}
+	for j := range d[0] {
+		d[0][j] = 2 * j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			sub := 0
+			if ra[i-1] != rb[j-1] {
+				sub = 2
+				if unicode.ToLower(ra[i-1]) == unicode.ToLower(rb[j-1]) {
+					sub = 1
+				}
+			}
+
+			d[i][j] = min(d[i-1][j]+2, d[i][j-1]+2, d[i-1][j-1]+sub)
+
+			// Adjacent transposition, as in "gk" for "kg"
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+2)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
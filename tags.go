@@ -0,0 +1,525 @@
+package si
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MarshalJSON returns the JSON encoding of v as json.Marshal does, except that
+// Unit fields tagged with a unit, such as
+//
+//	Pressure si.Unit `json:"pressure_kpa" si:"kPa"`
+//
+// are written as plain numbers in that unit: {"pressure_kpa":350}. This serves
+// consumers that keep the unit in the field name, as described in the README.
+// The tag takes any expression ParseUnit accepts, including "°C", and a field
+// that cannot be expressed in it is a DimensionMismatchError. *Unit fields may
+// be tagged too. A nil or zero Unit is null, and omitted with omitempty. The
+// json tag's string option writes the number as a JSON string.
+//
+// Tagged fields are found in nested structs, pointers, slices, arrays and maps.
+// Everything else is left to encoding/json: v is copied into a value whose type
+// mirrors v's with the tagged fields replaced by their numbers, and that value
+// is passed to json.Marshal, so field names, embedding, name conflicts, map keys
+// and the json tag's options behave exactly as they do there. Types with their
+// own MarshalJSON or MarshalText, and values held in interfaces, are encoded by
+// encoding/json as they are. Recursive types cannot be mirrored, so si tags in
+// them are an error.
+//
+// Example:
+//
+//	type Reading struct {
+//	    Pressure    si.Unit `json:"pressure_kpa" si:"kPa"`
+//	    Temperature si.Unit `json:"temperature_c" si:"°C"`
+//	}
+//	data, _ := si.MarshalJSON(Reading{si.Pascals(350000), si.MustParse("21.5 °C")})
+//	// {"pressure_kpa":350,"temperature_c":21.5}
+func MarshalJSON(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || !hasTags(rv.Type()) {
+		return json.Marshal(v)
+	}
+	m, err := mirrorOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	mv := reflect.New(m.typ).Elem()
+	if err := m.copyTo(mv, rv, true); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mv.Interface())
+}
+
+// UnmarshalJSON decodes JSON into the value v points to as json.Unmarshal does,
+// reading the numbers of Unit fields with an si tag in the tagged unit; see
+// MarshalJSON. A tagged field may also hold any encoding Unit.UnmarshalJSON
+// reads, such as "3.5 bar", which must have the dimension of the tagged unit,
+// or the error is a DimensionMismatchError. Keys are matched to fields by
+// json.Unmarshal itself.
+//
+// Example:
+//
+//	var r Reading
+//	err := si.UnmarshalJSON([]byte(`{"pressure_kpa":350,"temperature_c":21.5}`), &r)
+//	// r.Pressure is 350 kPa
+func UnmarshalJSON(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("cannot unmarshal JSON into non-pointer %T", v)
+	}
+	if !hasTags(rv.Type()) {
+		return json.Unmarshal(data, v)
+	}
+	m, err := mirrorOf(rv.Type().Elem())
+	if err != nil {
+		return err
+	}
+	mv := reflect.New(m.typ)
+	if err := m.copyTo(mv.Elem(), rv.Elem(), false); err != nil {
+		return err
+	}
+	// json.Unmarshal keeps decoding after type errors, so what it decoded is
+	// copied back either way
+	err = json.Unmarshal(data, mv.Interface())
+	if copyErr := m.copyFrom(rv.Elem(), mv.Elem()); err == nil {
+		err = copyErr
+	}
+	return err
+}
+
+// unitType is the type of Unit, the only type an si tag may be put on
+var unitType = reflect.TypeFor[Unit]()
+
+// rawMessageType is the type tagged fields have in a mirror
+var rawMessageType = reflect.TypeFor[json.RawMessage]()
+
+// taggedTypes caches whether a type contains si tags, keyed by reflect.Type
+var taggedTypes sync.Map
+
+// jsonMirrors caches the mirrors of types with si tags, keyed by reflect.Type
+var jsonMirrors sync.Map
+
+// hasTags reports whether values of type t contain Unit fields with an si tag
+// that encoding/json would not handle by itself
+func hasTags(t reflect.Type) bool {
+	if cached, ok := taggedTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	tagged := findTags(t, map[reflect.Type]bool{})
+	taggedTypes.Store(t, tagged)
+	return tagged
+}
+
+// findTags walks t for si tags, visiting every type once so recursive types end
+func findTags(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] || selfEncoding(t) {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return findTags(t.Elem(), seen)
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() && !f.Anonymous || f.Tag.Get("json") == "-" {
+				continue
+			}
+			if _, ok := f.Tag.Lookup("si"); ok || findTags(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selfEncoding reports whether a type has its own JSON or text methods, which
+// encoding/json uses instead of walking its fields
+func selfEncoding(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{
+		reflect.TypeFor[json.Marshaler](),
+		reflect.TypeFor[json.Unmarshaler](),
+		reflect.TypeFor[encoding.TextMarshaler](),
+		reflect.TypeFor[encoding.TextUnmarshaler](),
+	} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonMirror describes a type with si tags and the type that stands in for it
+// when encoding/json is called. Types without si tags are their own mirror.
+type jsonMirror struct {
+	typ reflect.Type
+	// elem is the mirror of the element of pointers, slices, arrays and maps
+	elem *jsonMirror
+	// fields are the fields of a struct that are in its mirror, in order
+	fields []mirrorField
+}
+
+// mirrorField is a field of a struct mirror
+type mirrorField struct {
+	// index is the index of the field in the original struct
+	index int
+	name  string
+	// symbol is the unit of the si tag, empty for untagged fields
+	symbol string
+	// pointer reports whether a tagged field is a *Unit
+	pointer bool
+	// quoted reports whether a tagged field has the json tag's string option
+	quoted bool
+	// mirror is the mirror of the type of an untagged field
+	mirror *jsonMirror
+}
+
+// mirrorOf returns the mirror of t
+func mirrorOf(t reflect.Type) (*jsonMirror, error) {
+	if cached, ok := jsonMirrors.Load(t); ok {
+		return cached.(*jsonMirror), nil
+	}
+	m, err := buildMirror(t, false, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	jsonMirrors.Store(t, m)
+	return m, nil
+}
+
+// buildMirror builds the mirror of t. Embedded structs are copied even without
+// tags, as reflect.StructOf cannot embed types with methods.
+func buildMirror(t reflect.Type, embedded bool, building map[reflect.Type]bool) (*jsonMirror, error) {
+	if !embedded && !hasTags(t) {
+		return &jsonMirror{typ: t}, nil
+	}
+	if building[t] {
+		return nil, fmt.Errorf("si tags in recursive type %s are not supported", t)
+	}
+	building[t] = true
+	defer delete(building, t)
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		elem, err := buildMirror(t.Elem(), embedded, building)
+		if err != nil {
+			return nil, err
+		}
+		m := &jsonMirror{elem: elem}
+		switch t.Kind() {
+		case reflect.Pointer:
+			m.typ = reflect.PointerTo(elem.typ)
+		case reflect.Slice:
+			m.typ = reflect.SliceOf(elem.typ)
+		case reflect.Array:
+			m.typ = reflect.ArrayOf(t.Len(), elem.typ)
+		default:
+			m.typ = reflect.MapOf(t.Key(), elem.typ)
+		}
+		return m, nil
+	}
+
+	taken := map[string]bool{}
+	for i := range t.NumField() {
+		taken[t.Field(i).Name] = true
+	}
+	m := &jsonMirror{}
+	var fields []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		inlined := f.Anonymous && ft.Kind() == reflect.Struct
+		// Fields encoding/json ignores are left out
+		if !f.IsExported() && !inlined || f.Tag.Get("json") == "-" {
+			continue
+		}
+
+		mf := mirrorField{index: i, name: f.Name}
+		sf := reflect.StructField{Name: f.Name, Tag: f.Tag}
+		if symbol, ok := f.Tag.Lookup("si"); ok {
+			switch f.Type {
+			case unitType:
+			case reflect.PointerTo(unitType):
+				mf.pointer = true
+			default:
+				return nil, fmt.Errorf("si tag on field %s of type %s, want si.Unit", f.Name, f.Type)
+			}
+			if _, err := ParseUnit(symbol); err != nil {
+				return nil, fmt.Errorf("si tag on field %s: %w", f.Name, err)
+			}
+			_, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			mf.symbol, mf.quoted = symbol, slices.Contains(strings.Split(opts, ","), "string")
+			sf.Type = rawMessageType
+		} else {
+			elem, err := buildMirror(f.Type, inlined, building)
+			if err != nil {
+				return nil, err
+			}
+			mf.mirror, sf.Type = elem, elem.typ
+		}
+		// encoding/json names other embedded fields after their type, which is
+		// the name they have. Inlined structs are found by type, so they may
+		// have any unused exported name.
+		if inlined && mf.mirror != nil {
+			sf.Anonymous = true
+			sf.Name = "Embedded" + strconv.Itoa(i)
+			for taken[sf.Name] {
+				sf.Name += "_"
+			}
+			taken[sf.Name] = true
+		}
+		m.fields = append(m.fields, mf)
+		fields = append(fields, sf)
+	}
+	m.typ = reflect.StructOf(fields)
+	return m, nil
+}
+
+// copyTo copies src into dst, a settable value of the mirror type. When
+// encoding, tagged units are written as numbers; when decoding they are marked
+// unread, so that copyFrom leaves the fields the JSON does not mention alone.
+func (m *jsonMirror) copyTo(dst, src reflect.Value, encode bool) error {
+	if m.typ == src.Type() {
+		dst.Set(src)
+		return nil
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return nil
+		}
+		p := reflect.New(m.elem.typ)
+		if err := m.elem.copyTo(p.Elem(), src.Elem(), encode); err != nil {
+			return err
+		}
+		dst.Set(p)
+	case reflect.Slice, reflect.Array:
+		if src.Kind() == reflect.Slice {
+			if src.IsNil() {
+				return nil
+			}
+			dst.Set(reflect.MakeSlice(m.typ, src.Len(), src.Len()))
+		}
+		for i := range src.Len() {
+			if err := m.elem.copyTo(dst.Index(i), src.Index(i), encode); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+		dst.Set(reflect.MakeMapWithSize(m.typ, src.Len()))
+		elem := reflect.New(m.elem.typ).Elem()
+		for iter := src.MapRange(); iter.Next(); {
+			elem.SetZero()
+			if err := m.elem.copyTo(elem, iter.Value(), encode); err != nil {
+				return err
+			}
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+	default:
+		for i, f := range m.fields {
+			fv, out := src.Field(f.index), dst.Field(i)
+			if f.symbol == "" {
+				if err := f.mirror.copyTo(out, fv, encode); err != nil {
+					return err
+				}
+				continue
+			}
+			if !encode {
+				out.Set(reflect.ValueOf(unread))
+				continue
+			}
+			raw, err := encodeTaggedUnit(fv, f)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+			out.Set(reflect.ValueOf(raw))
+		}
+	}
+	return nil
+}
+
+// unread marks a tagged field json.Unmarshal has not written to. What it writes
+// is never empty, and the field of a value it allocates is nil. The slice has no
+// capacity, so RawMessage.UnmarshalJSON cannot write into it.
+var unread = json.RawMessage{}
+
+// copyFrom copies src, a value of the mirror type json.Unmarshal decoded into,
+// back into dst, decoding the tagged units
+func (m *jsonMirror) copyFrom(dst, src reflect.Value) error {
+	if m.typ == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			if !dst.IsNil() {
+				dst.SetZero()
+			}
+			return nil
+		}
+		if dst.IsNil() {
+			if !dst.CanSet() {
+				return fmt.Errorf("json: cannot set embedded pointer to unexported struct: %v", dst.Type().Elem())
+			}
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return m.elem.copyFrom(dst.Elem(), src.Elem())
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		// json.Unmarshal decodes into the elements a slice already has
+		s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		reflect.Copy(s, dst)
+		for i := range src.Len() {
+			if err := m.elem.copyFrom(s.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := range dst.Len() {
+			if err := m.elem.copyFrom(dst.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		}
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		for iter := src.MapRange(); iter.Next(); {
+			elem.SetZero()
+			if old := dst.MapIndex(iter.Key()); old.IsValid() {
+				elem.Set(old)
+			}
+			if err := m.elem.copyFrom(elem, iter.Value()); err != nil {
+				return err
+			}
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+	default:
+		// As in json.Unmarshal, the other fields are decoded after an error
+		var first error
+		for i, f := range m.fields {
+			fv, in := dst.Field(f.index), src.Field(i)
+			var err error
+			if f.symbol == "" {
+				err = f.mirror.copyFrom(fv, in)
+			} else if err = decodeTaggedUnit(in.Interface().(json.RawMessage), fv, f); err != nil {
+				err = fmt.Errorf("field %s: %w", f.name, err)
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+	return nil
+}
+
+// encodeTaggedUnit returns the number of a Unit or *Unit field in the tagged
+// unit, or nil, which encoding/json writes as null, for a nil or zero one
+func encodeTaggedUnit(fv reflect.Value, f mirrorField) (json.RawMessage, error) {
+	if f.pointer {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+	// An unset unit has no dimension to check, so it is written like a nil one
+	if fv.IsZero() {
+		return nil, nil
+	}
+	value, err := fv.Interface().(Unit).In(f.symbol)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if f.quoted {
+		return json.Marshal(string(data))
+	}
+	return data, nil
+}
+
+// decodeTaggedUnit decodes a number in the tagged unit, or any encoding
+// Unit.UnmarshalJSON reads of the same dimension, into a Unit or *Unit field
+func decodeTaggedUnit(raw json.RawMessage, fv reflect.Value, f mirrorField) error {
+	switch {
+	case raw == nil:
+		// The field is in a value json.Unmarshal allocated, and not in the JSON
+		fv.SetZero()
+		return nil
+	case len(raw) == 0:
+		return nil
+	}
+	raw = bytes.TrimSpace(raw)
+	if string(raw) == "null" {
+		if f.pointer {
+			fv.SetZero()
+		}
+		return nil
+	}
+
+	// With the string option the value is JSON inside a JSON string
+	if f.quoted {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil || !json.Valid([]byte(s)) {
+			return fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal %s", raw)
+		}
+		raw = bytes.TrimSpace([]byte(s))
+	}
+
+	target, err := ParseUnit(f.symbol)
+	if err != nil {
+		return err
+	}
+
+	var u Unit
+	if raw[0] == '"' || raw[0] == '{' {
+		if err := decodeJSON(DefaultRegistry(), raw, &u); err != nil {
+			return err
+		}
+		if u.Dimension != target.Dimension {
+			return &DimensionMismatchError{Op: "decode", Left: u.Dimension, Right: target.Dimension}
+		}
+	} else {
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		u = applyValue(target, value)
+	}
+
+	if f.pointer {
+		fv.Set(reflect.ValueOf(&u))
+		return nil
+	}
+	fv.Set(reflect.ValueOf(u))
+	return nil
+}
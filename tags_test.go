@@ -0,0 +1,250 @@
+package si_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// reading is a legacy payload with the units in the field names
+type reading struct {
+	Sensor      string   `json:"sensor"`
+	Pressure    si.Unit  `json:"pressure_kpa" si:"kPa"`
+	Temperature si.Unit  `json:"temperature_c" si:"°C"`
+	Flow        *si.Unit `json:"flow_lpm,omitempty" si:"L/min"`
+	Speed       si.Unit  `json:"speed"`
+}
+
+// TestMarshalJSONTags verifies that tagged fields are written as numbers in their unit
+func TestMarshalJSONTags(t *testing.T) {
+	r := reading{
+		Sensor:      "p1",
+		Pressure:    si.Pascals(350000),
+		Temperature: si.MustParse("21.5 °C"),
+		Speed:       si.MustParse("36 km/h"),
+	}
+	data, err := si.MarshalJSON(r)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error: %v", err)
+	}
+	want := `{"sensor":"p1","pressure_kpa":350,"temperature_c":21.5,"speed":"10 m/s"}`
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+}
+
+// TestUnmarshalJSONTags verifies that numbers are read in the tagged unit
+func TestUnmarshalJSONTags(t *testing.T) {
+	var r reading
+	data := `{"sensor":"p1","pressure_kpa":350,"temperature_c":21.5,"flow_lpm":12,"speed":"10 m/s"}`
+	if err := si.UnmarshalJSON([]byte(data), &r); err != nil {
+		t.Fatalf("UnmarshalJSON() error: %v", err)
+	}
+	if r.Sensor != "p1" || !r.Pressure.Equals(si.Pascals(350000)) || !r.Speed.Equals(si.MustParse("10 m/s")) {
+		t.Errorf("UnmarshalJSON() = %+v", r)
+	}
+	if !sameUnit(r.Temperature, si.MustParse("21.5 °C")) {
+		t.Errorf("UnmarshalJSON() temperature = %#v, want 21.5 °C", r.Temperature)
+	}
+	if r.Flow == nil || !r.Flow.Equals(si.MustParse("12 L/min")) {
+		t.Errorf("UnmarshalJSON() flow = %v, want 12 L/min", r.Flow)
+	}
+}
+
+// TestJSONTagsNested verifies that tags are found in slices, maps, pointers and embedded structs
+func TestJSONTagsNested(t *testing.T) {
+	type site struct {
+		reading
+		Readings []reading           `json:"readings"`
+		Latest   map[string]*reading `json:"latest"`
+	}
+	want := site{
+		reading:  reading{Sensor: "s", Pressure: si.Pascals(1000)},
+		Readings: []reading{{Pressure: si.Pascals(2000)}, {Pressure: si.Pascals(3000)}},
+		Latest:   map[string]*reading{"p1": {Pressure: si.Pascals(4000)}},
+	}
+	data, err := si.MarshalJSON(want)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error: %v", err)
+	}
+
+	var got site
+	if err := si.UnmarshalJSON(data, &got); err != nil {
+		t.Fatalf("UnmarshalJSON(%s) error: %v", data, err)
+	}
+	if got.Sensor != "s" || !got.Pressure.Equals(want.Pressure) || len(got.Readings) != 2 ||
+		!got.Readings[1].Pressure.Equals(want.Readings[1].Pressure) ||
+		got.Latest["p1"] == nil || !got.Latest["p1"].Pressure.Equals(want.Latest["p1"].Pressure) {
+		t.Errorf("round trip of %s = %+v", data, got)
+	}
+}
+
+// TestUnmarshalJSONTagsDimension verifies that units in a tagged field must match its dimension
+func TestUnmarshalJSONTagsDimension(t *testing.T) {
+	var r reading
+	if err := si.UnmarshalJSON([]byte(`{"pressure_kpa":"3.5 bar"}`), &r); err != nil || !r.Pressure.Equals(si.Pascals(350000)) {
+		t.Errorf("UnmarshalJSON(3.5 bar) = %v, %v, want 350 kPa", r.Pressure, err)
+	}
+
+	var mismatch *si.DimensionMismatchError
+	err := si.UnmarshalJSON([]byte(`{"pressure_kpa":"3.5 m"}`), &r)
+	if !errors.As(err, &mismatch) {
+		t.Errorf("UnmarshalJSON(3.5 m) error = %v, want DimensionMismatchError", err)
+	}
+	if _, err := si.MarshalJSON(reading{Pressure: si.Meters(1)}); !errors.As(err, &mismatch) {
+		t.Errorf("MarshalJSON(1 m) error = %v, want DimensionMismatchError", err)
+	}
+}
+
+// TestJSONTagsInvalid verifies that tags on other types and unknown units are rejected
+func TestJSONTagsInvalid(t *testing.T) {
+	var wrongType struct {
+		Pressure float64 `si:"kPa"`
+	}
+	if _, err := si.MarshalJSON(wrongType); err == nil {
+		t.Error("MarshalJSON() with a float64 field tagged succeeded")
+	}
+
+	var unknown struct {
+		Pressure si.Unit `si:"furlongs"`
+	}
+	if err := si.UnmarshalJSON([]byte(`{"Pressure":1}`), &unknown); err == nil {
+		t.Error("UnmarshalJSON() with an unknown unit tagged succeeded")
+	}
+	if err := si.UnmarshalJSON([]byte(`{}`), unknown); err == nil {
+		t.Error("UnmarshalJSON() into a non-pointer succeeded")
+	}
+}
+
+// sample and plainSample are encoded alike, the first by si.MarshalJSON and
+// the second by json.Marshal
+type sample struct {
+	Pressure si.Unit `json:"p,string,omitempty" si:"kPa"`
+}
+
+type plainSample struct {
+	Pressure float64 `json:"p,string,omitempty"`
+}
+
+type (
+	origin struct {
+		Name string
+		ID   int `json:"id"`
+	}
+	owner  struct{ Name string }
+	remark struct{ Note string }
+	code   int
+)
+
+func (c code) MarshalText() ([]byte, error) { return []byte(fmt.Sprintf("c%d", int(c))), nil }
+
+func (c *code) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "c%d", (*int)(c))
+	return err
+}
+
+type document struct {
+	origin
+	owner
+	*remark
+	ByCode  map[code]sample
+	ByID    map[int]*sample
+	Samples [2]sample
+	Latest  *sample `json:"latest"`
+}
+
+type plainDocument struct {
+	origin
+	owner
+	*remark
+	ByCode  map[code]plainSample
+	ByID    map[int]*plainSample
+	Samples [2]plainSample
+	Latest  *plainSample `json:"latest"`
+}
+
+// TestJSONTagsMatchEncodingJSON verifies that names, conflicts, embedding, map
+// keys, the string option and case-insensitive keys behave as in encoding/json
+func TestJSONTagsMatchEncodingJSON(t *testing.T) {
+	doc := document{
+		origin:  origin{Name: "a", ID: 1},
+		owner:   owner{Name: "b"},
+		remark:  &remark{Note: "n"},
+		ByCode:  map[code]sample{3: {si.Pascals(1500)}, 1: {}},
+		ByID:    map[int]*sample{10: {si.Pascals(2000)}, 2: nil},
+		Samples: [2]sample{{si.Pascals(250)}},
+	}
+	plain := plainDocument{
+		origin:  origin{Name: "a", ID: 1},
+		owner:   owner{Name: "b"},
+		remark:  &remark{Note: "n"},
+		ByCode:  map[code]plainSample{3: {1.5}, 1: {}},
+		ByID:    map[int]*plainSample{10: {2}, 2: nil},
+		Samples: [2]plainSample{{0.25}},
+	}
+	got, err := si.MarshalJSON(doc)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error: %v", err)
+	}
+	want, _ := json.Marshal(plain)
+	if string(got) != string(want) {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+
+	inputs := []string{
+		string(want),
+		`{"ID":7,"NAME":"x","note":"m","bycode":{"c3":{"p":"4","P":"5"}},"BYID":{"2":{"P":"6"}},"Latest":{"P":"7"}}`,
+		`{"samples":[{"p":"8"},null],"latest":null,"byid":{"10":null}}`,
+		`{"id":"x","ByID":{"2":{"p":"6"}},"latest":{"p":5}}`,
+		`{"latest":{"p":"x"}}`,
+	}
+	for _, input := range inputs {
+		var gotDoc document
+		var wantDoc plainDocument
+		gotErr := si.UnmarshalJSON([]byte(input), &gotDoc)
+		wantErr := json.Unmarshal([]byte(input), &wantDoc)
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Errorf("UnmarshalJSON(%s) error = %v, want %v", input, gotErr, wantErr)
+			continue
+		}
+		got, _ := si.MarshalJSON(gotDoc)
+		want, _ := json.Marshal(wantDoc)
+		if string(got) != string(want) {
+			t.Errorf("UnmarshalJSON(%s) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+// TestUnmarshalJSONTagsKeepsFields verifies that fields the JSON does not
+// mention keep their values, as with json.Unmarshal
+func TestUnmarshalJSONTagsKeepsFields(t *testing.T) {
+	r := reading{Sensor: "p1", Pressure: si.Pascals(1000)}
+	readings := []reading{r}
+	byName := map[string]reading{"p1": r, "p2": r}
+	if err := si.UnmarshalJSON([]byte(`{"sensor":"p3"}`), &r); err != nil || !r.Pressure.Equals(si.Pascals(1000)) {
+		t.Errorf("UnmarshalJSON() pressure = %v, %v, want 1 kPa", r.Pressure, err)
+	}
+	if err := si.UnmarshalJSON([]byte(`[{"sensor":"p3"},{"sensor":"p4"}]`), &readings); err != nil ||
+		!readings[0].Pressure.Equals(si.Pascals(1000)) || readings[1].Pressure != (si.Unit{}) {
+		t.Errorf("UnmarshalJSON() slice = %+v, %v", readings, err)
+	}
+	// Map entries in the JSON are replaced rather than merged
+	if err := si.UnmarshalJSON([]byte(`{"p1":{"sensor":"p3"}}`), &byName); err != nil ||
+		byName["p1"].Pressure != (si.Unit{}) || !byName["p2"].Pressure.Equals(si.Pascals(1000)) {
+		t.Errorf("UnmarshalJSON() map = %+v, %v", byName, err)
+	}
+}
+
+// TestJSONTagsRecursive verifies that si tags in recursive types are rejected
+func TestJSONTagsRecursive(t *testing.T) {
+	type node struct {
+		Length   si.Unit `si:"m"`
+		Children []node
+	}
+	if _, err := si.MarshalJSON(node{Length: si.Meters(1)}); err == nil {
+		t.Error("MarshalJSON() of a recursive type succeeded")
+	}
+}
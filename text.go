@@ -0,0 +1,121 @@
+package si
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// AppendText implements encoding.TextAppender, appending the text MarshalText writes.
+func (u Unit) AppendText(b []byte) ([]byte, error) {
+	value, symbol, err := textParts(u)
+	if err != nil {
+		return nil, err
+	}
+	b = strconv.AppendFloat(b, value, 'g', -1, 64)
+	if symbol == "" {
+		return b, nil
+	}
+	return append(append(b, ' '), symbol...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, which YAML and TOML encoders,
+// flag.TextVar and JSON map keys use. Unlike String, the text reads back with
+// UnmarshalText and the default registry as the same value, dimension and kind,
+// bit for bit.
+//
+// The value is written with the fewest digits that read back exactly, in the
+// coherent SI unit of the dimension, e.g. "101325 Pa", "5000 N*m" for a torque
+// or "0.001 m^3/s". Absolute temperatures keep their scale ("21.5 °C") when a
+// value on the scale reads back as exactly the same kelvins, as it does for any
+// temperature parsed from text. Computed temperatures, in particular far below
+// the zero of their scale, may have no such value; they are written in kelvins
+// and read back as differences. Units
+// that have no such symbol, such as an untagged energy, are written in base units
+// ("20 (kg*m^2)/s^2") so that they do not come back with a kind. A unit whose
+// symbols the default registry cannot parse, such as one from a registry created
+// with ContextOptions.AngleDimension, is an error.
+//
+// Example:
+//
+//	text, _ := si.MustParse("101.325 kPa").MarshalText() // 101325 Pa
+//	speeds := map[si.Unit]string{si.MustParse("100 km/h"): "fast"}
+//	data, _ := json.Marshal(speeds) // {"27.77777777777778 m/s":"fast"}
+func (u Unit) MarshalText() ([]byte, error) {
+	return u.AppendText(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading any text Parse
+// accepts, such as "100 km/h". Symbols are resolved with the default registry.
+func (u *Unit) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// textParts returns the value and the symbol MarshalText writes. The symbol is
+// empty for plain numbers.
+func textParts(u Unit) (float64, string, error) {
+	if value, symbol, ok := u.affineText(); ok {
+		return value, symbol, nil
+	}
+	if isPlainNumber(u) {
+		return u.Value, "", nil
+	}
+	symbol, err := textSymbol(u)
+	if err != nil {
+		return 0, "", err
+	}
+	return u.Value, symbol, nil
+}
+
+// textSymbol returns the coherent symbol MarshalText writes for the unit, and
+// falls back to base units when that symbol would not read back as the unit
+func textSymbol(u Unit) (string, error) {
+	if symbol, err := formatUnitDimension(u); err == nil && readsBackAs(symbol, u) {
+		return symbol, nil
+	}
+
+	formatter := NewDefaultFormatter()
+	formatter.Options.CollapseSymbols = false
+	if symbol, err := formatter.FormatDimension(u.Dimension); err == nil && readsBackAs(symbol, u) {
+		return symbol, nil
+	}
+	return "", fmt.Errorf("cannot marshal unit of dimension %s as text", u.Dimension)
+}
+
+// readsBackAs reports whether symbol parses as a coherent unit of the dimension and kind of u
+func readsBackAs(symbol string, u Unit) bool {
+	parsed, err := ParseUnit(symbol)
+	return err == nil && parsed.Value == 1 && !parsed.IsAbsolute() &&
+		parsed.Dimension == u.Dimension && parsed.Kind == u.Kind
+}
+
+// affineText returns the value of an absolute temperature on its own scale and
+// the symbol of the scale. The subtraction of the offset may round, so the value
+// is moved ulp by ulp towards u until it reads back as exactly u. It reports
+// false when no value does, as two neighbouring values read back on either side
+// of u, leaving the unit to be written in kelvins.
+func (u Unit) affineText() (float64, string, bool) {
+	value, symbol, ok := affineParts(u)
+	if !ok {
+		return 0, "", false
+	}
+	_, scale, _ := DefaultRegistry().affineSymbol(u.Offset)
+
+	for i := 0; i < 64; i++ {
+		got := applyValue(scale, value).Value
+		if got == u.Value {
+			return value, symbol, true
+		}
+		next := math.Nextafter(value, math.Copysign(math.Inf(1), (u.Value-got)/scale.step()))
+		if (applyValue(scale, next).Value-u.Value)*(got-u.Value) < 0 {
+			return 0, "", false
+		}
+		value = next
+	}
+	return 0, "", false
+}
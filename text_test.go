@@ -0,0 +1,150 @@
+package si_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"math"
+	"testing"
+
+	"github.com/gurre/si"
+)
+
+// Unit is usable wherever encoders and flag.TextVar look for text methods
+var (
+	_ encoding.TextMarshaler   = si.Unit{}
+	_ encoding.TextAppender    = si.Unit{}
+	_ encoding.TextUnmarshaler = (*si.Unit)(nil)
+)
+
+// sameUnit reports whether two units are identical, down to the bits of the value
+func sameUnit(a, b si.Unit) bool {
+	return math.Float64bits(a.Value) == math.Float64bits(b.Value) &&
+		a.Dimension == b.Dimension && a.Offset == b.Offset && a.Kind == b.Kind
+}
+
+// TestMarshalText verifies the text of common units
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		unit si.Unit
+		want string
+	}{
+		{si.MustParse("101.325 kPa"), "101325 Pa"},
+		{si.MustParse("5 kN*m"), "5000 N*m"},
+		{si.MustParse("2 kJ"), "2000 J"},
+		{si.Newtons(10).Mul(si.Meters(2)), "20 (kg*m^2)/s^2"},
+		{si.MustParse("21.5 °C"), "21.5 °C"},
+		{si.MustParse("3 L/s"), "0.003 m^3/s"},
+		{si.MustParse("90 °"), "1.5707963267948966 rad"},
+		{si.Scalar(0.85), "0.85"},
+		{si.Meters(math.Inf(1)), "+Inf m"},
+	}
+	for _, tt := range tests {
+		got, err := tt.unit.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%v) error: %v", tt.unit, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("MarshalText(%v) = %q, want %q", tt.unit, got, tt.want)
+		}
+	}
+}
+
+// TestTextRoundTripStandardContext verifies that every unit of the standard
+// context survives MarshalText and UnmarshalText unchanged
+func TestTextRoundTripStandardContext(t *testing.T) {
+	values := []float64{1, 0.1, -273.15, 123.456e-7, 6.02214076e23, 1.0 / 3}
+	for _, symbol := range si.NewStandardContext().Symbols() {
+		unit, err := si.ParseUnit(symbol)
+		if err != nil {
+			t.Fatalf("ParseUnit(%q) error: %v", symbol, err)
+		}
+		for _, v := range values {
+			want := si.New(v, symbol)
+			text, err := want.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText(%v %s) error: %v", v, symbol, err)
+			}
+
+			var got si.Unit
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%q) error: %v", text, err)
+			}
+			if !sameUnit(got, want) {
+				t.Errorf("%v %s (%#v): %q read back as %#v", v, symbol, unit, text, got)
+			}
+		}
+	}
+}
+
+// TestMarshalTextTemperatureFallback verifies that an absolute temperature no
+// value on its scale reads back as is written exactly in kelvins
+func TestMarshalTextTemperatureFallback(t *testing.T) {
+	cold := si.Celsius(0)
+	cold.Value = 1e-300
+	text, err := cold.MarshalText()
+	if err != nil || string(text) != "1e-300 K" {
+		t.Fatalf("MarshalText() = %q, %v, want %q", text, err, "1e-300 K")
+	}
+
+	var got si.Unit
+	if err := got.UnmarshalText(text); err != nil || got.Value != cold.Value || got.Dimension != cold.Dimension {
+		t.Errorf("UnmarshalText(%q) = %#v, %v, want %v K", text, got, err, cold.Value)
+	}
+
+	// Computed temperatures keep their scale when a value on it reads back exactly
+	warm, _ := si.Fahrenheit(70).Add(si.Kelvins(1.0 / 3))
+	text, _ = warm.MarshalText()
+	if err := got.UnmarshalText(text); err != nil || !sameUnit(got, warm) {
+		t.Errorf("UnmarshalText(%q) = %#v, %v, want %#v", text, got, err, warm)
+	}
+}
+
+// TestAppendText verifies that AppendText appends to the buffer
+func TestAppendText(t *testing.T) {
+	b, err := si.Meters(3).AppendText([]byte("length="))
+	if err != nil || string(b) != "length=3 m" {
+		t.Errorf("AppendText() = %q, %v, want %q", b, err, "length=3 m")
+	}
+}
+
+// TestUnmarshalTextInvalid verifies that malformed text is an error
+func TestUnmarshalTextInvalid(t *testing.T) {
+	var u si.Unit
+	if err := u.UnmarshalText([]byte("3 parsecs per fortnight")); err == nil {
+		t.Error("UnmarshalText() succeeded, want error")
+	}
+}
+
+// TestTextJSONMapKeys verifies that units work as JSON map keys
+func TestTextJSONMapKeys(t *testing.T) {
+	limits := map[si.Unit]string{si.MustParse("2.5 bar"): "high"}
+	data, err := json.Marshal(limits)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if string(data) != `{"250000 Pa":"high"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var decoded map[si.Unit]string
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if decoded[si.Pascals(250000)] != "high" {
+		t.Errorf("Unmarshal() = %v", decoded)
+	}
+}
+
+// TestTextFlag verifies that a unit can be a command-line flag
+func TestTextFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var limit si.Unit
+	fs.TextVar(&limit, "limit", si.MustParse("100 km/h"), "speed limit")
+	if err := fs.Parse([]string{"-limit", "36 km/h"}); err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if !limit.Equals(si.Meters(10).Div(si.Second)) {
+		t.Errorf("limit = %v, want 36 km/h", limit)
+	}
+}
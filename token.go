@@ -14,7 +14,7 @@ const (
 	EOF
 	Identifier
 	Number
-	Multiply // * or ·
+	Multiply // *, · or ×
 	Divide   // /
 	Power    // ^
 	LParen   // (
@@ -14,18 +14,27 @@ type Tokenizer struct {
 	input    string
 	tokens   []Token
 	position int
+	err      error
 }
 
-// NewTokenizer creates a new tokenizer for the input
+// NewTokenizer creates a new tokenizer for the input.
+// If the input cannot be tokenized, the tokens before the problem are still
+// available and Err reports the *SyntaxError.
 func NewTokenizer(input string) *Tokenizer {
-	tokens, _ := tokenizeFully(input)
+	tokens, err := tokenizeFully(input)
 	return &Tokenizer{
 		input:    input,
 		tokens:   tokens,
 		position: 0,
+		err:      err,
 	}
 }
 
+// Err returns the error that stopped tokenization, if any
+func (t *Tokenizer) Err() error {
+	return t.err
+}
+
 // Next returns the next token and advances
 func (t *Tokenizer) Next() Token {
 	if t.position >= len(t.tokens) {
@@ -64,9 +73,6 @@ func readRuneAt(s string, pos int) (rune, int) {
 
 // tokenizeFully tokenizes the entire input at once
 func tokenizeFully(input string) ([]Token, error) {
-	// Normalize the input to make parsing easier
-	input = normalizeInput(input)
-
 	var tokens []Token
 	var pos int
 
@@ -87,7 +93,7 @@ func tokenizeFully(input string) ([]Token, error) {
 			tokens = append(tokens, Token{Kind: RParen, Value: ")", Pos: scanner.Position{Offset: pos}})
 			pos += width
 			continue
-		} else if r == '*' || r == '·' {
+		} else if r == '*' || r == '·' || r == '×' {
 			val := string(r)
 			tokens = append(tokens, Token{Kind: Multiply, Value: val, Pos: scanner.Position{Offset: pos}})
 			pos += width
@@ -102,22 +108,24 @@ func tokenizeFully(input string) ([]Token, error) {
 			continue
 		}
 
-		// Numbers
-		if unicode.IsDigit(r) {
-			start := pos
-			for pos < len(input) {
-				r, width := readRuneAt(input, pos)
-				if !unicode.IsDigit(r) && r != '.' {
-					break
-				}
-				pos += width
-			}
-			numStr := input[start:pos]
-			_, err := strconv.ParseFloat(numStr, 64)
+		// Unicode superscripts are a compact exponent: "m²" is m^2 and "s⁻¹" is s^-1
+		if isSuperscript(r) {
+			exp, end := scanSuperscript(input, pos)
+			tokens = append(tokens, Token{Kind: Power, Value: "^", Pos: scanner.Position{Offset: pos}})
+			tokens = append(tokens, Token{Kind: Number, Value: exp, Pos: scanner.Position{Offset: pos}})
+			pos = end
+			continue
+		}
+
+		// Numbers, including a sign where an operand is expected ("^-1", "-40 °F").
+		// Only the leading number is a value that may carry a "×10^n" factor.
+		if startsNumber(input, pos, signAllowed(tokens)) {
+			numStr, end, err := scanNumber(input, pos, len(tokens) == 0)
 			if err != nil {
-				return tokens, fmt.Errorf("invalid number %q at position %d", numStr, start)
+				return tokens, err
 			}
-			tokens = append(tokens, Token{Kind: Number, Value: numStr, Pos: scanner.Position{Offset: start}})
+			tokens = append(tokens, Token{Kind: Number, Value: numStr, Pos: scanner.Position{Offset: pos}})
+			pos = end
 			continue
 		}
 
@@ -137,7 +145,7 @@ func tokenizeFully(input string) ([]Token, error) {
 		}
 
 		// Invalid character
-		return tokens, fmt.Errorf("invalid character %q at position %d", r, pos)
+		return tokens, &SyntaxError{Input: input, Offset: pos, Msg: fmt.Sprintf("invalid character %q", r)}
 	}
 
 	// Add EOF token
@@ -146,9 +154,203 @@ func tokenizeFully(input string) ([]Token, error) {
 	return tokens, nil
 }
 
+// signAllowed reports whether a leading + or - may start a number at this point,
+// which is the case at the start of input and right after an operator or "(".
+// Elsewhere a sign is not part of the unit grammar.
+func signAllowed(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	switch tokens[len(tokens)-1].Kind {
+	case Power, LParen, Multiply, Divide:
+		return true
+	default:
+		return false
+	}
+}
+
+// startsNumber reports whether a number begins at pos
+func startsNumber(input string, pos int, signed bool) bool {
+	r, width := readRuneAt(input, pos)
+	if signed && isSign(r) {
+		pos += width
+		r, width = readRuneAt(input, pos)
+	}
+	if r == '.' {
+		r, _ = readRuneAt(input, pos+width)
+	}
+	return isASCIIDigit(r)
+}
+
+// scanNumber reads a decimal number starting at pos: an optional sign, digits with an
+// optional fraction, an optional exponent ("1e-3") and, when value is set, an optional
+// power-of-ten factor ("1.5×10^3", "2·10⁻⁶"). It returns the number in strconv.ParseFloat
+// syntax so that the parser never has to know about the alternative spellings.
+//
+// The factor is only folded into the leading value: elsewhere "m^2*10^3" is m² times
+// a thousand, not m raised to 2000.
+func scanNumber(input string, pos int, value bool) (string, int, error) {
+	start := pos
+	var mantissa strings.Builder
+
+	r, width := readRuneAt(input, pos)
+	if isSign(r) {
+		if r != '+' {
+			mantissa.WriteByte('-')
+		}
+		pos += width
+	}
+
+	digitsStart := pos
+	for pos < len(input) && (isASCIIDigit(rune(input[pos])) || input[pos] == '.') {
+		pos++
+	}
+	mantissa.WriteString(input[digitsStart:pos])
+
+	// The exponent marker only counts when digits follow, so "5eV" stays 5 eV
+	exp := 0
+	if pos < len(input) && (input[pos] == 'e' || input[pos] == 'E') {
+		if expStr, end, ok := scanSignedDigits(input, pos+1); ok {
+			e, err := strconv.Atoi(expStr)
+			if err != nil {
+				return "", pos, &SyntaxError{Input: input, Offset: pos, Msg: fmt.Sprintf("invalid exponent %q", expStr)}
+			}
+			exp += e
+			pos = end
+		}
+	}
+
+	if value {
+		if factor, end, ok := scanTimesTen(input, pos); ok {
+			exp += factor
+			pos = end
+		}
+	}
+
+	numStr := mantissa.String()
+	if exp != 0 {
+		numStr += "e" + strconv.Itoa(exp)
+	}
+	if _, err := strconv.ParseFloat(numStr, 64); err != nil {
+		return "", pos, &SyntaxError{Input: input, Offset: start, Msg: fmt.Sprintf("invalid number %q", input[start:pos])}
+	}
+	return numStr, pos, nil
+}
+
+// scanTimesTen reads a power-of-ten factor such as "×10^3", "*10^-6" or "·10⁻⁶"
+// written after a number. Spaces around the multiplication sign are allowed.
+// It returns the exponent and the position after the factor.
+func scanTimesTen(input string, pos int) (int, int, bool) {
+	p := skipSpaces(input, pos)
+	r, width := readRuneAt(input, p)
+	if r != '×' && r != '*' && r != '·' {
+		return 0, pos, false
+	}
+	p = skipSpaces(input, p+width)
+	if !strings.HasPrefix(input[p:], "10") {
+		return 0, pos, false
+	}
+	p = skipSpaces(input, p+2)
+
+	var expStr string
+	r, width = readRuneAt(input, p)
+	switch {
+	case r == '^':
+		digits, end, ok := scanSignedDigits(input, skipSpaces(input, p+width))
+		if !ok {
+			return 0, pos, false
+		}
+		expStr, p = digits, end
+	case isSuperscript(r):
+		expStr, p = scanSuperscript(input, p)
+	default:
+		return 0, pos, false
+	}
+
+	exp, err := strconv.Atoi(expStr)
+	if err != nil {
+		return 0, pos, false
+	}
+	return exp, p, true
+}
+
+// scanSignedDigits reads an optionally signed run of ASCII digits starting at pos
+func scanSignedDigits(input string, pos int) (string, int, bool) {
+	var b strings.Builder
+	r, width := readRuneAt(input, pos)
+	if isSign(r) {
+		if r != '+' {
+			b.WriteByte('-')
+		}
+		pos += width
+	}
+
+	start := pos
+	for pos < len(input) && isASCIIDigit(rune(input[pos])) {
+		pos++
+	}
+	if pos == start {
+		return "", pos, false
+	}
+	b.WriteString(input[start:pos])
+	return b.String(), pos, true
+}
+
+// superscripts maps Unicode superscript characters to their ASCII counterparts
+var superscripts = map[rune]rune{
+	'⁰': '0', '¹': '1', '²': '2', '³': '3', '⁴': '4',
+	'⁵': '5', '⁶': '6', '⁷': '7', '⁸': '8', '⁹': '9',
+	'⁻': '-', '⁺': '+',
+}
+
+// isSuperscript checks if a rune is a superscript digit or sign
+func isSuperscript(r rune) bool {
+	_, ok := superscripts[r]
+	return ok
+}
+
+// scanSuperscript reads a run of superscript characters and returns it as ASCII
+func scanSuperscript(input string, pos int) (string, int) {
+	var b strings.Builder
+	for pos < len(input) {
+		r, width := readRuneAt(input, pos)
+		ascii, ok := superscripts[r]
+		if !ok {
+			break
+		}
+		if ascii != '+' {
+			b.WriteRune(ascii)
+		}
+		pos += width
+	}
+	return b.String(), pos
+}
+
+// skipSpaces returns the position of the first non-space rune at or after pos
+func skipSpaces(input string, pos int) int {
+	for pos < len(input) {
+		r, width := readRuneAt(input, pos)
+		if !isSpace(r) {
+			break
+		}
+		pos += width
+	}
+	return pos
+}
+
+// isSign checks for a plus or minus sign, including the typographic minus (U+2212)
+func isSign(r rune) bool {
+	return r == '+' || r == '-' || r == '−'
+}
+
+// isASCIIDigit checks for 0-9; unicode.IsDigit would admit digits strconv cannot parse
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
 // isSpecialIdentifierStart checks if a rune is a valid start of an identifier (special characters)
 func isSpecialIdentifierStart(r rune) bool {
-	return r == '%' || r == '°' || r == 'µ' || r == 'μ' || r == 'Ω'
+	return r == '%' || r == '°' || r == '′' || r == '″' || r == 'µ' || r == 'μ' || r == 'Ω'
 }
 
 // Helpers for tokenization
@@ -156,25 +358,10 @@ func isSpace(r rune) bool {
 	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
 }
 
+// isIdentifierChar checks if a rune may continue an identifier. Underscores join
+// words in symbols such as "fl_oz" and "gal_imp".
 func isIdentifierChar(r rune) bool {
-	return unicode.IsLetter(r) || unicode.IsDigit(r) || isSpecialIdentifierStart(r)
-}
-
-// normalizeInput prepares input for tokenization
-func normalizeInput(input string) string {
-	// Add spaces around operators for clear tokenization
-	input = strings.Replace(input, "(", " ( ", -1)
-	input = strings.Replace(input, ")", " ) ", -1)
-	input = strings.Replace(input, "/", " / ", -1)
-	input = strings.Replace(input, "*", " * ", -1)
-	input = strings.Replace(input, "·", " · ", -1)
-	input = strings.Replace(input, "^", " ^ ", -1)
-
-	// Normalize spaces
-	for strings.Contains(input, "  ") {
-		input = strings.Replace(input, "  ", " ", -1)
-	}
-	return strings.TrimSpace(input)
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || isSpecialIdentifierStart(r) || r == '_'
 }
 
 // tokenize is a legacy function for testing
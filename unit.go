@@ -1,27 +1,83 @@
 package si
 
 import (
-	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"math"
-	"strconv"
-	"strings"
 )
 
-// Dimension represents the exponents of the 7 SI base units.
-// The index positions are: [Length, Mass, Time, Current, Temperature, Substance, Luminosity].
-// For example, a meter is Dimension{1,0,0,0,0,0,0} and a second is Dimension{0,0,1,0,0,0,0}.
-type Dimension [7]int
-
 // Unit represents a physical quantity with a value and dimension
 // This is the core type of the package, combining a numeric value with its physical dimension.
 // All operations on physical quantities in this package use this type.
 type Unit struct {
 	// Value is the scalar magnitude of the physical quantity in SI base units
 	Value float64
-	// Dimension contains the exponents for each of the 7 SI base dimensions
+	// Dimension contains the exponents of the 17 base dimensions (the SI base
+	// dimensions, information, angle and custom ones) and their common denominator
 	Dimension Dimension
+	// Offset is the zero point, in SI base units, of an affine scale such as °C or °F.
+	// A non-zero Offset marks the quantity as an absolute point on that scale rather
+	// than a difference; Value still holds the absolute SI value (kelvins for °C).
+	// It is zero for every quantity produced by arithmetic, which yields differences.
+	Offset float64
+	// Kind tells apart quantities of the same dimension, such as torque and energy.
+	// It is KindNone unless the unit was parsed from a symbol of a specific kind
+	// or tagged with WithKind; see QuantityKind.
+	Kind QuantityKind
+}
+
+// Affine creates an offset unit whose zero lies at offset (in SI base units) and
+// whose step is the given unit. The result represents one step on that scale,
+// which is what DefineUnit expects for an affine symbol.
+//
+// Example:
+//
+//	celsius := Affine(Kelvin, 273.15)                            // 1 °C = 274.15 K
+//	fahrenheit := Affine(Kelvin.Mul(Scalar(5.0/9.0)), 459.67*5/9) // 1 °F = 255.93 K
+func Affine(step Unit, offset float64) Unit {
+	return Unit{Value: offset + step.Value, Dimension: step.Dimension, Offset: offset}
+}
+
+// IsAbsolute reports whether the unit is an absolute point on an affine scale,
+// such as a temperature parsed from "25 °C", rather than a difference.
+func (u Unit) IsAbsolute() bool {
+	return u.Offset != 0
+}
+
+// DropAngle removes the angle exponent of a unit from a registry created with
+// ContextOptions.AngleDimension, returning to the SI view of angles as
+// dimensionless. Angular velocity in rad/s becomes a frequency in Hz.
+//
+// Example:
+//
+//	ctx := NewContext(ContextOptions{AngleDimension: true})
+//	omega, _ := ctx.Parse("6.28 rad/s")
+//	freq := omega.DropAngle() // 6.28 Hz
+func (u Unit) DropAngle() Unit {
+	u.Dimension = u.Dimension.withoutAngle()
+	// The angle kinds go with the angle, so that the result reads as a frequency
+	if u.Kind == KindAngle || u.Kind == KindAngularVelocity {
+		u.Kind = KindNone
+	}
+	return u
+}
+
+// step returns the size of one unit on the unit's own scale.
+// For ordinary units this is the value itself; for affine units the offset is removed.
+func (u Unit) step() float64 {
+	return u.Value - u.Offset
+}
+
+// onScale returns the point n steps from the zero of the affine unit scale
+func onScale(scale Unit, n float64) Unit {
+	scale.Value = n*scale.step() + scale.Offset
+	return scale
+}
+
+// delta reinterprets an affine unit as a difference on its scale, so that "°C/s"
+// means kelvins per second rather than an absolute temperature per second.
+func (u Unit) delta() Unit {
+	return Unit{Value: u.step(), Dimension: u.Dimension, Kind: u.Kind}
 }
 
 // Scalar creates a dimensionless unit
@@ -46,14 +102,12 @@ func Scalar(value float64) Unit {
 //	acceleration := Meters(9.81).Div(Second.Pow(2))
 //	force := mass.Mul(acceleration)  // 735.75 N
 func (u Unit) Mul(v Unit) Unit {
-	var dim Dimension
-	for i := range dim {
-		dim[i] = u.Dimension[i] + v.Dimension[i]
-	}
-	return Unit{
-		Value:     u.Value * v.Value,
-		Dimension: dim,
+	r := Unit{Value: u.Value * v.Value, Dimension: mulDimensions(u.Dimension, v.Dimension)}
+	// Untagged operands, by far the common case, never give a kind
+	if u.Kind != KindNone || v.Kind != KindNone {
+		r.Kind = productKind(u, v, r.Dimension)
 	}
+	return r
 }
 
 // Div divides two units
@@ -67,38 +121,95 @@ func (u Unit) Mul(v Unit) Unit {
 //	time := Minutes(30)
 //	speed := distance.Div(time)  // 33.33 m/s
 func (u Unit) Div(v Unit) Unit {
-	var dim Dimension
-	for i := range dim {
-		dim[i] = u.Dimension[i] - v.Dimension[i]
-	}
-	return Unit{
-		Value:     u.Value / v.Value,
-		Dimension: dim,
+	r := Unit{Value: u.Value / v.Value, Dimension: divDimensions(u.Dimension, v.Dimension)}
+	// Untagged operands, by far the common case, never give a kind
+	if u.Kind != KindNone || v.Kind != KindNone {
+		r.Kind = quotientKind(u, v, r.Dimension)
 	}
+	return r
 }
 
 // Pow raises a unit to a power
-// This is used for operations like squaring distances or taking the square root of an area.
+// This is used for operations like squaring distances or inverting a time into a frequency.
 // All dimension exponents are multiplied by the given power.
+// Use Sqrt, Cbrt or PowRat for fractional powers.
 //
 // Example:
 //
 //	// Calculate area from length: A = l²
 //	length := Meters(4)
 //	area := length.Pow(2)  // 16 m²
+func (u Unit) Pow(exp int) Unit {
+	return Unit{
+		Value:     pow(u.Value, exp),
+		Dimension: powDimension(u.Dimension, exp, 1),
+	}
+}
+
+// PowRat raises a unit to the rational power num/den.
+// All dimension exponents are multiplied by num/den, so the result may have
+// fractional dimensions such as the Hz^(1/2) in a noise density of V/√Hz.
+// It panics if den is zero.
+//
+// Example:
 //
 //	// Calculate radius from volume: r = ∛(3V/4π)
 //	volumeTerm := Meter.Pow(3).Mul(Scalar(3.0/4.0/math.Pi))
-//	radius := volumeTerm.Pow(1.0/3.0)
-func (u Unit) Pow(exp int) Unit {
-	var dim Dimension
-	for i := range dim {
-		dim[i] = u.Dimension[i] * exp
+//	radius := volumeTerm.PowRat(1, 3)
+func (u Unit) PowRat(num, den int) Unit {
+	if den == 0 {
+		panic("si: PowRat with zero denominator")
 	}
+	if den < 0 {
+		num, den = -num, -den
+	}
+	g := gcd(abs(num), den)
+	num, den = num/g, den/g
+
 	return Unit{
-		Value:     pow(u.Value, exp),
-		Dimension: dim,
+		Value:     powRat(u.Value, num, den),
+		Dimension: powDimension(u.Dimension, num, den),
+	}
+}
+
+// Sqrt returns the square root of a unit.
+//
+// Example:
+//
+//	side := Meters(16).Mul(Meter).Sqrt() // 4 m
+//	density := Volts(3e-9).Div(Hertz.Sqrt()) // 3 nV/√Hz
+func (u Unit) Sqrt() Unit {
+	return u.PowRat(1, 2)
+}
+
+// Cbrt returns the cube root of a unit.
+//
+// Example:
+//
+//	side := Meter.Pow(3).Mul(Scalar(27)).Cbrt() // 3 m
+func (u Unit) Cbrt() Unit {
+	return u.PowRat(1, 3)
+}
+
+// powRat calculates x^(num/den) for a reduced fraction with a positive denominator.
+// Square and cube roots use math.Sqrt and math.Cbrt, which are exact for perfect
+// powers where math.Pow may be off by an ulp. Odd roots of negative numbers are
+// real, matching math.Cbrt.
+func powRat(x float64, num, den int) float64 {
+	var root float64
+	switch {
+	case den == 1:
+		return pow(x, num)
+	case den == 2:
+		root = math.Sqrt(x)
+	case den == 3:
+		root = math.Cbrt(x)
+	case x < 0 && den%2 == 1:
+		root = -math.Pow(-x, 1/float64(den))
+	default:
+		root = math.Pow(x, 1/float64(den))
 	}
+	return pow(root, num)
 }
 
 // pow is a helper function to calculate x^n for integer exponents
@@ -118,7 +229,8 @@ func pow(x float64, n int) float64 {
 	return x * pow(x, n-1)
 }
 
-// Compare returns -1, 0, 1 if u <, ==, > v respectively. Returns error if dimensions differ.
+// Compare returns -1, 0, 1 if u <, ==, > v respectively. Returns error if dimensions differ,
+// or a KindMismatchError if the units are of different kinds, such as a torque and an energy.
 // This is used to compare units with the same dimensions, such as comparing two lengths.
 //
 // Example:
@@ -128,7 +240,10 @@ func pow(x float64, n int) float64 {
 //	result, _ := dist1.Compare(dist2) // result will be -1 because 1 km < 1.5 km
 func (u Unit) Compare(v Unit) (int, error) {
 	if u.Dimension != v.Dimension {
-		return 0, errors.New("cannot compare units with different dimensions")
+		return 0, &DimensionMismatchError{Op: "compare", Left: u.Dimension, Right: v.Dimension}
+	}
+	if _, err := mergeKinds("compare", u, v); err != nil {
+		return 0, err
 	}
 	switch {
 	case math.Abs(u.Value-v.Value) < 1e-12:
@@ -140,8 +255,10 @@ func (u Unit) Compare(v Unit) (int, error) {
 	}
 }
 
-// MarshalJSON encodes the unit as a string like "100 km/h".
+// MarshalJSON encodes the unit as a string like "100 km/h", as String writes it.
 // This enables JSON serialization of SI units with their dimensions and prefixes.
+// SetDefaultJSONMode selects one of the other encodings of JSONMode, and
+// JSONUnit selects one for a single field.
 //
 // Example:
 //
@@ -151,11 +268,17 @@ func (u Unit) Compare(v Unit) (int, error) {
 //	reading := Reading{Pressure: Pascals(101325)}
 //	data, _ := json.Marshal(reading) // {"pressure":"101.325 kPa"}
 func (u Unit) MarshalJSON() ([]byte, error) {
-	return json.Marshal(FormatUnitWithPrefix(u))
+	return marshalJSON(u, DefaultJSONMode())
 }
 
-// UnmarshalJSON parses a unit string from JSON like "100 km/h".
+// UnmarshalJSON parses a unit string from JSON like "100 km/h", or any other
+// encoding of JSONMode: {"value":101325,"unit":"Pa"}, {"value":101325,"dimension":{...}}
+// or a bare number in base SI units. A bare number carries no dimension, so it
+// keeps the dimension and kind the unit already has; set the field to the
+// expected unit, such as si.Pascal, before decoding.
 // This enables JSON deserialization of SI units back into native Unit objects.
+// Symbols are resolved with the default registry; use Registry.DecodeJSON to
+// decode with a different one.
 //
 // Example:
 //
@@ -163,19 +286,7 @@ func (u Unit) MarshalJSON() ([]byte, error) {
 //	err := json.Unmarshal([]byte(`{"pressure":"101.325 kPa"}`), &reading)
 //	// reading.Pressure will be 101325 Pa
 func (u *Unit) UnmarshalJSON(data []byte) error {
-	var input string
-	if err := json.Unmarshal(data, &input); err != nil {
-		return err
-	}
-
-	parsed, err := Parse(input)
-	if err != nil {
-		return err
-	}
-
-	u.Value = parsed.Value
-	u.Dimension = parsed.Dimension
-	return nil
+	return decodeJSON(DefaultRegistry(), data, u)
 }
 
 // MarshalXML encodes the unit as an XML element with value and dimension attributes.
@@ -198,39 +309,7 @@ func (u Unit) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	}
 
 	// Format the dimension as a string
-	var dimParts []string
-	for i, exp := range u.Dimension {
-		if exp != 0 {
-			var dimName string
-			switch i {
-			case 0:
-				dimName = "L"
-			case 1:
-				dimName = "M"
-			case 2:
-				dimName = "T"
-			case 3:
-				dimName = "I"
-			case 4:
-				dimName = "Θ"
-			case 5:
-				dimName = "N"
-			case 6:
-				dimName = "J"
-			}
-
-			if exp == 1 {
-				dimParts = append(dimParts, dimName)
-			} else {
-				dimParts = append(dimParts, dimName+"^"+strconv.Itoa(exp))
-			}
-		}
-	}
-
-	dimensionStr := strings.Join(dimParts, "·")
-	if dimensionStr == "" {
-		dimensionStr = "1" // Dimensionless
-	}
+	dimensionStr := u.Dimension.String()
 
 	xu := xmlUnit{
 		Value:     u.Value,
@@ -265,13 +344,15 @@ func (u *Unit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return err
 	}
 
-	u.Value = parsed.Value
-	u.Dimension = parsed.Dimension
+	*u = parsed
 	return nil
 }
 
 // Add adds two units of the same dimension.
-// Returns an error if the dimensions don't match.
+// Returns an error if the dimensions don't match, if the units are of different
+// kinds (a torque and an energy), or if both units are absolute
+// points on an affine scale (adding 20 °C to 30 °C has no physical meaning).
+// Adding a difference to an absolute temperature yields an absolute temperature.
 // This is used for adding similar physical quantities, like two lengths or two masses.
 //
 // Example:
@@ -284,16 +365,31 @@ func (u *Unit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 //	// Error case: different dimensions
 //	time := Seconds(30)
 //	_, err := length1.Add(time) // err will not be nil
+//
+//	// Affine temperatures: absolute plus difference is allowed
+//	room := MustParse("20 °C")
+//	warmer, _ := room.Add(MustParse("5 K")) // 25 °C
+//	_, err = room.Add(room)                  // *AbsoluteTemperatureError
 func (u Unit) Add(v Unit) (Unit, error) {
 	if u.Dimension != v.Dimension {
-		return Unit{}, errors.New("cannot add units with different dimensions")
+		return Unit{}, &DimensionMismatchError{Op: "add", Left: u.Dimension, Right: v.Dimension}
+	}
+	kind, err := mergeKinds("add", u, v)
+	if err != nil {
+		return Unit{}, err
+	}
+	if u.IsAbsolute() && v.IsAbsolute() {
+		return Unit{}, &AbsoluteTemperatureError{Op: "add", Left: u, Right: v}
 	}
-	return Unit{u.Value + v.Value, u.Dimension}, nil
+	// At most one offset is non-zero, so the sum keeps the scale of the absolute operand
+	return Unit{Value: u.Value + v.Value, Dimension: u.Dimension, Offset: u.Offset + v.Offset, Kind: kind}, nil
 }
 
 // ConvertTo converts one unit to another unit of the same dimension.
 // Returns an error if the dimensions don't match or if division by zero would occur.
 // This is used to convert between different units of the same physical dimension.
+// When the target is an affine unit such as °F, the value is measured from the
+// target's zero point, so 25 °C converts to 77 °F.
 //
 // Example:
 //
@@ -305,23 +401,55 @@ func (u Unit) Add(v Unit) (Unit, error) {
 //	energy := Joules(3600000)
 //	kWh := Watt.Mul(Scalar(1000)).Mul(Hours(1))
 //	result, _ := energy.ConvertTo(kWh) // 1 kWh
+//
+//	// Convert an absolute temperature to degrees Fahrenheit
+//	fahrenheit, _ := ParseUnit("°F")
+//	result, _ = MustParse("25 °C").ConvertTo(fahrenheit) // 77
 func (u Unit) ConvertTo(target Unit) (Unit, error) {
 	if u.Dimension != target.Dimension {
-		return Unit{}, errors.New("cannot convert units with different dimensions")
+		return Unit{}, &DimensionMismatchError{Op: "convert", Left: u.Dimension, Right: target.Dimension}
 	}
 
-	if target.Value == 0 {
+	if target.step() == 0 {
 		return Unit{}, errors.New("cannot convert to a unit with zero value")
 	}
 
-	// Scale factor is u.Value / target.Value
-	scaleFactor := u.Value / target.Value
+	// Scale factor is the distance from the target's zero point in target steps;
+	// for ordinary units the offset is zero and this is u.Value / target.Value
+	scaleFactor := (u.Value - target.Offset) / target.step()
 
 	return Unit{Value: scaleFactor, Dimension: u.Dimension}, nil
 }
 
+// In returns the value of the unit expressed in the target unit expression.
+// The target is parsed with the default registry, so any expression ParseUnit
+// accepts can be used, including prefixes and affine scales. Use Registry.In for
+// units from another registry, such as one created with ContextOptions.AngleDimension.
+// Returns an error if the target cannot be parsed or has a different dimension.
+//
+// Example:
+//
+//	kWh, _ := Joules(3.6e6).In("kW*h")   // 1
+//	psi, _ := Pascals(101325).In("psi")  // 14.696
+//	degF, _ := MustParse("25 °C").In("°F") // 77
+func (u Unit) In(target string) (float64, error) {
+	return DefaultRegistry().In(u, target)
+}
+
+// FormatAs formats the unit in the target unit expression, keeping the target
+// as written, e.g. "1 kW*h" rather than "3.6 MJ".
+// See In for how the target is interpreted and FormatIn to control the precision.
+//
+// Example:
+//
+//	str, _ := Joules(3.6e6).FormatAs("kW*h") // "1 kW*h"
+func (u Unit) FormatAs(target string) (string, error) {
+	return FormatIn(u, target, -1)
+}
+
 // Equals compares two units for equality with appropriate tolerance.
 // This method accounts for floating-point imprecision when comparing unit values.
+// Units of different kinds, such as a torque and an energy, are never equal.
 //
 // Example:
 //
@@ -337,6 +465,9 @@ func (u Unit) Equals(v Unit) bool {
 	if u.Dimension != v.Dimension {
 		return false
 	}
+	if _, err := mergeKinds("compare", u, v); err != nil {
+		return false
+	}
 
 	// Use relative epsilon for large values
 	eps := 1e-12
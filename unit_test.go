@@ -2,6 +2,7 @@ package si
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"testing"
 )
@@ -17,7 +18,7 @@ func TestJSONMarshalling(t *testing.T) {
 		{"energy", Joule.Mul(Scalar(5000)), `"5 kJ"`},
 		{"power", Watt.Mul(Scalar(1500)), `"1.5 kW"`},
 		{"dimensionless", Scalar(0.75), `"0.75"`},
-		{"temperature", Celsius(25), `"298.15 K"`},
+		{"temperature", Celsius(25), `"25 °C"`},
 		{"pressure", Pascal.Mul(Scalar(101325)), `"101.325 kPa"`},
 		{"force", Newton.Mul(Scalar(50)), `"50 N"`},
 	}
@@ -294,3 +295,249 @@ func TestConvertZeroTargetValue(t *testing.T) {
 func almostEqual(a, b, tolerance float64) bool {
 	return math.Abs(a-b) <= tolerance || math.Abs(1-a/b) <= tolerance
 }
+
+// TestParseCelsius verifies that "25 °C" parses to an absolute temperature in kelvins
+func TestParseCelsius(t *testing.T) {
+	got, err := Parse("25 °C")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got.Dimension != Temperature {
+		t.Errorf("Dimension = %v, want %v", got.Dimension, Temperature)
+	}
+	if !almostEqual(got.Value, 298.15, 1e-9) {
+		t.Errorf("Value = %v, want 298.15", got.Value)
+	}
+	if !got.IsAbsolute() {
+		t.Error("Parsed Celsius temperature should be absolute")
+	}
+}
+
+// TestParseFahrenheit verifies that "77 °F" parses to the same temperature as 25 °C
+func TestParseFahrenheit(t *testing.T) {
+	got, err := Parse("77 °F")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !almostEqual(got.Value, 298.15, 1e-9) {
+		t.Errorf("Value = %v, want 298.15", got.Value)
+	}
+}
+
+// TestConvertCelsiusToFahrenheit verifies ConvertTo measures from the target's zero point
+func TestConvertCelsiusToFahrenheit(t *testing.T) {
+	fahrenheit, err := ParseUnit("°F")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+
+	result, err := MustParse("25 °C").ConvertTo(fahrenheit)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !almostEqual(result.Value, 77, 1e-9) {
+		t.Errorf("Value = %v, want 77", result.Value)
+	}
+}
+
+// TestConvertKelvinToCelsius verifies plain kelvin values convert onto the Celsius scale
+func TestConvertKelvinToCelsius(t *testing.T) {
+	celsius, err := ParseUnit("degC")
+	if err != nil {
+		t.Fatalf("ParseUnit error: %v", err)
+	}
+
+	result, err := Kelvins(300).ConvertTo(celsius)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !almostEqual(result.Value, 26.85, 1e-9) {
+		t.Errorf("Value = %v, want 26.85", result.Value)
+	}
+}
+
+// TestFormatCelsius verifies absolute temperatures format on their original scale
+func TestFormatCelsius(t *testing.T) {
+	if got := MustParse("25 °C").String(); got != "25 °C" {
+		t.Errorf("String() = %q, want %q", got, "25 °C")
+	}
+	if got := MustParse("-40 °F").String(); got != "-40 °F" {
+		t.Errorf("String() = %q, want %q", got, "-40 °F")
+	}
+}
+
+// TestJSONCelsiusRoundTrip verifies absolute temperatures survive JSON encoding
+func TestJSONCelsiusRoundTrip(t *testing.T) {
+	data, err := json.Marshal(MustParse("21.5 °C"))
+	if err != nil {
+		t.Fatalf("Failed to marshal unit: %v", err)
+	}
+	if string(data) != `"21.5 °C"` {
+		t.Errorf("JSON = %s, want %s", data, `"21.5 °C"`)
+	}
+
+	var got Unit
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to unmarshal unit: %v", err)
+	}
+	if !got.IsAbsolute() || !almostEqual(got.Value, 294.65, 1e-9) {
+		t.Errorf("Unmarshalled = %+v, want absolute 294.65 K", got)
+	}
+}
+
+// TestAddAbsoluteTemperatures verifies adding two absolute temperatures is rejected
+func TestAddAbsoluteTemperatures(t *testing.T) {
+	_, err := MustParse("20 °C").Add(MustParse("30 °C"))
+	if err == nil {
+		t.Error("Expected error adding two absolute temperatures")
+	}
+}
+
+// TestAddTemperatureDelta verifies a difference can be added to an absolute temperature
+func TestAddTemperatureDelta(t *testing.T) {
+	got, err := MustParse("20 °C").Add(MustParse("5 K"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !got.IsAbsolute() {
+		t.Error("Sum should stay absolute")
+	}
+	if s := got.String(); s != "25 °C" {
+		t.Errorf("String() = %q, want %q", s, "25 °C")
+	}
+}
+
+// TestAffineInCompoundUnit verifies an affine unit inside an expression acts as a difference
+func TestAffineInCompoundUnit(t *testing.T) {
+	got, err := Parse("2 °C/s")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	want := Kelvin.Div(Second).Mul(Scalar(2))
+	if !got.Equals(want) || got.IsAbsolute() {
+		t.Errorf("Parse(2 °C/s) = %+v, want %+v", got, want)
+	}
+}
+
+// TestNewCelsius verifies New places the value on the affine scale
+func TestNewCelsius(t *testing.T) {
+	got := New(100, "°C")
+	if !almostEqual(got.Value, 373.15, 1e-9) {
+		t.Errorf("Value = %v, want 373.15", got.Value)
+	}
+}
+
+// TestSqrtArea verifies the square root of an area is a length
+func TestSqrtArea(t *testing.T) {
+	side := Meters(16).Mul(Meter).Sqrt()
+	if side.Dimension != Length {
+		t.Errorf("Dimension = %v, want %v", side.Dimension, Length)
+	}
+	if side.Value != 4 {
+		t.Errorf("Value = %v, want 4", side.Value)
+	}
+}
+
+// TestCbrtVolume verifies the cube root of a volume is a length
+func TestCbrtVolume(t *testing.T) {
+	side := Meter.Pow(3).Mul(Scalar(27)).Cbrt()
+	if side.Dimension != Length {
+		t.Errorf("Dimension = %v, want %v", side.Dimension, Length)
+	}
+	if side.Value != 3 {
+		t.Errorf("Value = %v, want 3", side.Value)
+	}
+}
+
+// TestSqrtFrequencyRoundTrip verifies fractional dimensions square back to integers
+func TestSqrtFrequencyRoundTrip(t *testing.T) {
+	root := Hertz.Sqrt()
+	if root.Dimension.IsInteger() {
+		t.Fatal("Expected a fractional dimension")
+	}
+	if got := root.Mul(root); got.Dimension != Hertz.Dimension {
+		t.Errorf("Dimension = %v, want %v", got.Dimension, Hertz.Dimension)
+	}
+}
+
+// TestPowRatReduces verifies equivalent fractions give equal dimensions
+func TestPowRatReduces(t *testing.T) {
+	a := Meter.PowRat(2, 4)
+	b := Meter.PowRat(-1, -2)
+	if a.Dimension != b.Dimension {
+		t.Errorf("Dimensions differ: %v and %v", a.Dimension, b.Dimension)
+	}
+	if num, den := a.Dimension.Exponent(0); num != 1 || den != 2 {
+		t.Errorf("Exponent = %d/%d, want 1/2", num, den)
+	}
+}
+
+// TestPowRatZeroDenominator verifies a zero denominator panics
+func TestPowRatZeroDenominator(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for zero denominator")
+		}
+	}()
+	Meter.PowRat(1, 0)
+}
+
+// TestIn verifies reading a value in a target unit expression
+func TestIn(t *testing.T) {
+	got, err := Joules(3.6e6).In("kW*h")
+	if err != nil {
+		t.Fatalf("In error: %v", err)
+	}
+	if !almostEqual(got, 1, 1e-12) {
+		t.Errorf("In = %v, want 1", got)
+	}
+}
+
+// TestInAffine verifies converting an absolute temperature to another scale
+func TestInAffine(t *testing.T) {
+	got, err := MustParse("25 °C").In("°F")
+	if err != nil {
+		t.Fatalf("In error: %v", err)
+	}
+	if !almostEqual(got, 77, 1e-9) {
+		t.Errorf("In = %v, want 77", got)
+	}
+}
+
+// TestInDimensionMismatch verifies a target of another dimension is rejected
+func TestInDimensionMismatch(t *testing.T) {
+	var mismatch *DimensionMismatchError
+	if _, err := Joules(1).In("m"); !errors.As(err, &mismatch) {
+		t.Errorf("Expected *DimensionMismatchError, got %v", err)
+	}
+}
+
+// TestInUnknownUnit verifies an unparsable target is rejected
+func TestInUnknownUnit(t *testing.T) {
+	var unknown *UnknownUnitError
+	if _, err := Joules(1).In("kWx"); !errors.As(err, &unknown) {
+		t.Errorf("Expected *UnknownUnitError, got %v", err)
+	}
+}
+
+// TestFormatAs verifies the target is kept as written
+func TestFormatAs(t *testing.T) {
+	got, err := Joules(3.6e6).FormatAs("kW*h")
+	if err != nil {
+		t.Fatalf("FormatAs error: %v", err)
+	}
+	if got != "1 kW*h" {
+		t.Errorf("FormatAs = %q, want %q", got, "1 kW*h")
+	}
+}
+
+// TestFormatAsAffine verifies conversion noise is hidden
+func TestFormatAsAffine(t *testing.T) {
+	got, err := MustParse("25 °C").FormatAs("°F")
+	if err != nil {
+		t.Fatalf("FormatAs error: %v", err)
+	}
+	if got != "77 °F" {
+		t.Errorf("FormatAs = %q, want %q", got, "77 °F")
+	}
+}